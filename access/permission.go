@@ -6,4 +6,5 @@ const (
 	PermissionManageLeagues       = "org:leagues:manage"
 	PermissionManageTeams         = "org:teams:manage"
 	PermissionManageOrganizations = "org:sys_profile:manage"
+	PermissionAssignScouts        = "org:scouts:assign"
 )
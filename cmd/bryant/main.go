@@ -30,6 +30,16 @@ var envCfg struct {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate-config" {
+		if err := validateConfigs(os.Args[2:]); err != nil {
+			slog.Error("validating scouting configs", slog.Any("error", err))
+
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	if err := run(); err != nil {
 		slog.Error("running", slog.Any("error", err))
 
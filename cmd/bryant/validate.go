@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sportsbydata/backend/scouting"
+)
+
+// validateConfigs prints structural problems of scouting config files. JSON
+// files are detected by extension, everything else is parsed as YAML.
+// Without files the embedded default config is validated.
+func validateConfigs(files []string) error {
+	if len(files) == 0 {
+		return reportProblems("default", scouting.DefaultScoutingConfig)
+	}
+
+	var failed bool
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", f, err)
+		}
+
+		format := scouting.ConfigFormatYAML
+
+		if filepath.Ext(f) == ".json" {
+			format = scouting.ConfigFormatJSON
+		}
+
+		cfg, err := scouting.UnmarshalScoutingConfig(data, format)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", f, err)
+		}
+
+		if err = reportProblems(f, cfg); err != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		return errors.New("invalid scouting configs")
+	}
+
+	return nil
+}
+
+func reportProblems(name string, cfg scouting.ScoutingConfig) error {
+	pp := cfg.Problems()
+
+	for _, p := range pp {
+		fmt.Printf("%s: %s\n", name, p)
+	}
+
+	if len(pp) > 0 {
+		return fmt.Errorf("%s: %d problems", name, len(pp))
+	}
+
+	return nil
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/sportsbydata/backend/sbd"
 )
 
+// AccountFilter selects accounts. When Limit is set, accounts are ordered by
+// id and start after the After id.
 type AccountFilter struct {
 	OrganizationID string
 	ID             string
+	After          string
+	Limit          uint64
 }
 
 type Account struct {
@@ -0,0 +1,244 @@
+package scouting
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// ActionStats describes how efficient an action, or one of its options, was.
+// ActionOptionID is not set for action totals.
+type ActionStats struct {
+	ActionID            string
+	ActionOptionID      null.Value[string]
+	Possessions         uint
+	Points              uint
+	Turnovers           uint
+	PointsPerPossession float64
+	Frequency           float64
+	TurnoverRate        float64
+}
+
+type ActionAnalytics struct {
+	League []ActionStats
+	Teams  map[uuid.UUID][]ActionStats
+}
+
+type actionKey struct {
+	actionID string
+	optionID string
+}
+
+type actionCounter struct {
+	total  uint
+	counts map[actionKey]*ActionStats
+}
+
+func newActionCounter() *actionCounter {
+	return &actionCounter{
+		counts: make(map[actionKey]*ActionStats),
+	}
+}
+
+func (ac *actionCounter) add(p Possession, o Outcome) {
+	ac.total++
+
+	keys := []actionKey{{actionID: p.ActionID}}
+
+	if p.ActionOptionID.Valid {
+		keys = append(keys, actionKey{actionID: p.ActionID, optionID: p.ActionOptionID.V})
+	}
+
+	for _, k := range keys {
+		st, ok := ac.counts[k]
+		if !ok {
+			st = &ActionStats{
+				ActionID:       k.actionID,
+				ActionOptionID: null.NewValue(k.optionID, k.optionID != ""),
+			}
+
+			ac.counts[k] = st
+		}
+
+		st.Possessions++
+		st.Points += o.Points
+
+		if o.Turnover {
+			st.Turnovers++
+		}
+	}
+}
+
+// stats returns the counted actions in the given order, each action total
+// followed by its options.
+func (ac *actionCounter) stats(aa []Action) []ActionStats {
+	var ss []ActionStats
+
+	appendStats := func(k actionKey) {
+		st, ok := ac.counts[k]
+		if !ok {
+			return
+		}
+
+		st.PointsPerPossession = float64(st.Points) / float64(st.Possessions)
+		st.TurnoverRate = float64(st.Turnovers) / float64(st.Possessions)
+		st.Frequency = float64(st.Possessions) / float64(ac.total)
+
+		ss = append(ss, *st)
+	}
+
+	for _, a := range aa {
+		appendStats(actionKey{actionID: a.ID})
+
+		for _, o := range a.Options {
+			appendStats(actionKey{actionID: a.ID, optionID: o.ID})
+		}
+	}
+
+	return ss
+}
+
+// offenceTeam returns the team that had the ball during the possession.
+func offenceTeam(m Match, p Possession) uuid.UUID {
+	if p.Mode == ModeAttack {
+		return m.ScoutedTeamUUID
+	}
+
+	if m.ScoutedTeamUUID == m.HomeTeamUUID {
+		return m.AwayTeamUUID
+	}
+
+	return m.HomeTeamUUID
+}
+
+func computeActionAnalytics(cs configSet, mm []Match, mss []MatchScout, pp []Possession) ActionAnalytics {
+	matches := make(map[uuid.UUID]Match, len(mm))
+
+	for _, m := range mm {
+		matches[m.UUID] = m
+	}
+
+	league := newActionCounter()
+	teams := make(map[uuid.UUID]*actionCounter)
+
+	for _, p := range countedPossessions(mss, pp) {
+		m, ok := matches[p.MatchUUID]
+		if !ok {
+			continue
+		}
+
+		o, ok := cs.outcome(p)
+		if !ok {
+			continue
+		}
+
+		tuuid := offenceTeam(m, p)
+
+		tc, ok := teams[tuuid]
+		if !ok {
+			tc = newActionCounter()
+			teams[tuuid] = tc
+		}
+
+		league.add(p, o)
+		tc.add(p, o)
+	}
+
+	order := cs.actions()
+
+	aa := ActionAnalytics{
+		League: league.stats(order),
+		Teams:  make(map[uuid.UUID][]ActionStats, len(teams)),
+	}
+
+	for tuuid, tc := range teams {
+		aa.Teams[tuuid] = tc.stats(order)
+	}
+
+	return aa
+}
+
+// LeagueActionAnalytics reports action efficiency over finished matches of
+// a league season, the current one when seasonUUID is not set.
+func LeagueActionAnalytics(ctx context.Context, sdb *sqlx.DB, oid string, leagueUUID, seasonUUID uuid.UUID) (ActionAnalytics, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("league_uuid", leagueUUID.String()),
+	)
+
+	ll, err := SelectLeagues(ctx, sdb, LeagueFilter{
+		LeagueUUID:     leagueUUID,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(ll) > 0:
+		// OK.
+	case err == nil && len(ll) == 0:
+		return ActionAnalytics{}, sbd.NewNotFoundError("league")
+	default:
+		logger.Error("selecting leagues", slog.Any("error", err))
+
+		return ActionAnalytics{}, errInternal
+	}
+
+	season, err := resolveSeason(ctx, sdb, logger, oid, leagueUUID, seasonUUID)
+	if err != nil {
+		return ActionAnalytics{}, err
+	}
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		LeagueUUID:     leagueUUID,
+		SeasonUUID:     season.UUID,
+		OrganizationID: oid,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return ActionAnalytics{}, errInternal
+	}
+
+	if len(mm) == 0 {
+		return ActionAnalytics{Teams: make(map[uuid.UUID][]ActionStats)}, nil
+	}
+
+	muuids := make([]uuid.UUID, len(mm))
+
+	for i, m := range mm {
+		muuids[i] = m.UUID
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, MatchScoutFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return ActionAnalytics{}, errInternal
+	}
+
+	pp, err := SelectPossessions(ctx, sdb, PossessionFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return ActionAnalytics{}, errInternal
+	}
+
+	vv, err := selectScoutingConfigVersions(ctx, sdb, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting scouting config versions", slog.Any("error", err))
+
+		return ActionAnalytics{}, errInternal
+	}
+
+	return computeActionAnalytics(newConfigSet(vv, mss), mm, mss, pp), nil
+}
@@ -0,0 +1,99 @@
+package scouting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeActionAnalytics(t *testing.T) {
+	t.Parallel()
+
+	home := uuid.Must(uuid.NewV7())
+	away := uuid.Must(uuid.NewV7())
+	finished := null.NewValue(time.Now(), true)
+
+	m := Match{
+		UUID:            uuid.Must(uuid.NewV7()),
+		HomeTeamUUID:    home,
+		AwayTeamUUID:    away,
+		ScoutedTeamUUID: home,
+	}
+
+	mss := []MatchScout{
+		{MatchUUID: m.UUID, AccountID: "a", Mode: ModeAttackDefence, Submode: SubmodeAnyRules, FinishedAt: finished},
+	}
+
+	possession := func(mode Mode, action, option, outcome string) Possession {
+		return Possession{
+			MatchUUID:      m.UUID,
+			AccountID:      "a",
+			Mode:           mode,
+			ActionID:       action,
+			ActionOptionID: null.NewValue(option, option != ""),
+			OutcomeID:      outcome,
+		}
+	}
+
+	pp := []Possession{
+		possession(ModeAttack, "w5", "roll", "o2"),
+		possession(ModeAttack, "w5", "pop", "o3"),
+		possession(ModeAttack, "w5", "pop", "steal / to"),
+		possession(ModeAttack, "zone", "", "x2"),
+		possession(ModeDefence, "1x1", "", "o2"),
+	}
+
+	aa := computeActionAnalytics(singleConfigSet(DefaultScoutingConfig, mss), []Match{m}, mss, pp)
+
+	assert.Equal(t, []ActionStats{
+		{
+			ActionID:            "w5",
+			Possessions:         3,
+			Points:              5,
+			Turnovers:           1,
+			PointsPerPossession: 5.0 / 3,
+			Frequency:           0.75,
+			TurnoverRate:        1.0 / 3,
+		},
+		{
+			ActionID:            "w5",
+			ActionOptionID:      null.NewValue("roll", true),
+			Possessions:         1,
+			Points:              2,
+			PointsPerPossession: 2,
+			Frequency:           0.25,
+		},
+		{
+			ActionID:            "w5",
+			ActionOptionID:      null.NewValue("pop", true),
+			Possessions:         2,
+			Points:              3,
+			Turnovers:           1,
+			PointsPerPossession: 1.5,
+			Frequency:           0.5,
+			TurnoverRate:        0.5,
+		},
+		{
+			ActionID:    "zone",
+			Possessions: 1,
+			Frequency:   0.25,
+		},
+	}, aa.Teams[home])
+
+	assert.Equal(t, []ActionStats{
+		{
+			ActionID:            "1x1",
+			Possessions:         1,
+			Points:              2,
+			PointsPerPossession: 2,
+			Frequency:           1,
+		},
+	}, aa.Teams[away])
+
+	assert.Len(t, aa.League, 5)
+	assert.Equal(t, "1x1", aa.League[0].ActionID)
+	assert.Equal(t, 0.2, aa.League[0].Frequency)
+}
@@ -0,0 +1,224 @@
+package scouting
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// NewMatchScoutAssignment assigns a scouting slot of a match to an
+// organization account.
+type NewMatchScoutAssignment struct {
+	AccountID string  `json:"account_id"`
+	Mode      Mode    `json:"mode"`
+	Submode   Submode `json:"submode"`
+	Layout    string  `json:"layout"`
+}
+
+// AssignMatchScout creates a pending scouting slot for the account. The slot
+// holds its mode and submode until the account declines it. An account that
+// declined an earlier assignment of the match can be assigned again.
+func AssignMatchScout(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, na NewMatchScoutAssignment) (MatchScout, error) {
+	logger := slog.With(
+		slog.String("account_id", aid),
+		slog.String("organization_id", oid),
+		slog.String("match_uuid", matchUUID.String()),
+		slog.String("assignee_id", na.AccountID),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	ms, err := assignMatchScout(ctx, tx, logger, oid, aid, matchUUID, na)
+	if err != nil {
+		return MatchScout{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	return ms, nil
+}
+
+func assignMatchScout(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid, aid string, matchUUID uuid.UUID, na NewMatchScoutAssignment) (MatchScout, error) {
+	mm, err := SelectMatches(ctx, tx, MatchFilter{
+		UUID:           matchUUID,
+		Active:         true,
+		OrganizationID: oid,
+	}, true)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	m := mm[0]
+
+	aa, err := SelectAccounts(ctx, tx, AccountFilter{
+		ID:             na.AccountID,
+		OrganizationID: oid,
+	})
+	switch {
+	case err == nil && len(aa) > 0:
+		// OK.
+	case err == nil && len(aa) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("account")
+	default:
+		logger.Error("selecting accounts", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+		MatchUUID: &m.UUID,
+		Statuses:  claimingMatchScoutStatuses,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	oo, err := selectOrganizations(ctx, tx, OrganizationFilter{
+		IDs: []string{oid},
+	}, false)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	nms := NewMatchScout{
+		Mode:    na.Mode,
+		Submode: na.Submode,
+		Layout:  na.Layout,
+	}
+
+	if err := matchScoutable(m, oo[0].ScoutingConfig, na.AccountID, mss, nms); err != nil {
+		return MatchScout{}, sbd.NewValidationError(err.Error())
+	}
+
+	ms := MatchScout{
+		MatchUUID:     m.UUID,
+		AccountID:     na.AccountID,
+		Mode:          na.Mode,
+		Submode:       na.Submode,
+		Layout:        null.NewValue(na.Layout, na.Layout != ""),
+		LayoutActions: oo[0].ScoutingConfig.layoutActions(na.Layout),
+		ConfigVersion: oo[0].ScoutingConfigVersion,
+		Status:        MatchScoutStatusPending,
+		AssignedBy:    null.NewValue(aid, true),
+	}
+
+	n, err := insertMatchScout(ctx, tx, ms)
+	switch {
+	case err == nil && n > 0:
+		// OK.
+	case err == nil && n == 0:
+		return MatchScout{}, sbd.NewValidationError("account already scouting this match")
+	case errors.Is(err, sbd.ErrAlreadyExists):
+		return MatchScout{}, sbd.NewValidationError("mode and submode conflicts with other scouts")
+	default:
+		logger.Error("inserting match scout", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	return ms, nil
+}
+
+// RespondMatchScoutAssignment accepts or declines the pending assignment of
+// the account. Declining frees the mode and submode for other scouts.
+func RespondMatchScoutAssignment(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, accept bool) (MatchScout, error) {
+	logger := slog.With(
+		slog.String("account_id", aid),
+		slog.String("organization_id", oid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	mm, err := SelectMatches(ctx, tx, MatchFilter{
+		UUID:           matchUUID,
+		Active:         true,
+		OrganizationID: oid,
+	}, true)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+		MatchUUID: &matchUUID,
+		AccountID: &aid,
+		Statuses:  []MatchScoutStatus{MatchScoutStatusPending},
+	})
+	switch {
+	case err == nil && len(mss) > 0:
+		// OK.
+	case err == nil && len(mss) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("assignment")
+	default:
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	ms := mss[0]
+	ms.Status = MatchScoutStatusDeclined
+
+	if accept {
+		ms.Status = MatchScoutStatusAccepted
+	}
+
+	if err = updateMatchScout(ctx, tx, ms); err != nil {
+		logger.Error("updating match scout", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	return ms, nil
+}
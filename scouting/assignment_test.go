@@ -0,0 +1,133 @@
+package scouting
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+func (s *Suite) Test_AssignMatchScout() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	b := s.createAccount("o1", "2")
+
+	home, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "home",
+	})
+	s.Require().NoError(err)
+
+	away, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "away",
+	})
+	s.Require().NoError(err)
+
+	l, _, err := CreateLeague(context.Background(), s.sdb, "o1", NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
+	s.Require().NoError(err)
+
+	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	m, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", NewMatch{
+		LeagueUUID:   l.UUID,
+		HomeTeamUUID: home.UUID,
+		AwayTeamUUID: away.UUID,
+		StartsAt:     time.Now().Add(time.Hour),
+	})
+	s.Require().NoError(err)
+
+	_, err = AssignMatchScout(context.Background(), s.sdb, "o1", "coordinator", m.UUID, NewMatchScoutAssignment{
+		AccountID: "unknown",
+		Mode:      ModeAttack,
+		Submode:   SubmodeAllRules,
+	})
+	s.Assert().Equal(sbd.NewNotFoundError("account"), err)
+
+	ms, err := AssignMatchScout(context.Background(), s.sdb, "o1", "coordinator", m.UUID, NewMatchScoutAssignment{
+		AccountID: a.ID,
+		Mode:      ModeAttack,
+		Submode:   SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(MatchScoutStatusPending, ms.Status)
+	s.Assert().Equal("coordinator", ms.AssignedBy.V)
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", b.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeOurRules,
+	})
+	s.Assert().Equal(sbd.NewValidationError("mode and submode conflicts with other scouts"), err)
+
+	ms, err = RespondMatchScoutAssignment(context.Background(), s.sdb, "o1", a.ID, m.UUID, false)
+	s.Require().NoError(err)
+	s.Assert().Equal(MatchScoutStatusDeclined, ms.Status)
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", b.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	_, err = AssignMatchScout(context.Background(), s.sdb, "o1", "coordinator", m.UUID, NewMatchScoutAssignment{
+		AccountID: a.ID,
+		Mode:      ModeDefence,
+		Submode:   SubmodePlays,
+	})
+	s.Require().NoError(err)
+
+	ms, err = RespondMatchScoutAssignment(context.Background(), s.sdb, "o1", a.ID, m.UUID, true)
+	s.Require().NoError(err)
+	s.Assert().Equal(MatchScoutStatusAccepted, ms.Status)
+
+	_, err = RespondMatchScoutAssignment(context.Background(), s.sdb, "o1", a.ID, m.UUID, true)
+	s.Assert().Equal(sbd.NewNotFoundError("assignment"), err)
+
+	cnt := s.selectCount("match_scout", squirrel.And{
+		squirrel.Eq{"account_id": a.ID},
+		squirrel.Eq{"mode": ModeDefence},
+		squirrel.Eq{"status": MatchScoutStatusAccepted},
+	})
+	s.Assert().Equal(1, cnt)
+}
+
+func (s *Suite) Test_insertMatchScout() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	m := s.createMatch("o1")
+
+	ms := MatchScout{
+		MatchUUID:     m.UUID,
+		AccountID:     a.ID,
+		Mode:          ModeAttack,
+		Submode:       SubmodeAllRules,
+		ConfigVersion: 1,
+		Status:        MatchScoutStatusPending,
+	}
+
+	n, err := insertMatchScout(context.Background(), s.sdb, ms)
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(1), n)
+
+	// Only declined slots are replaced.
+	ms.Mode = ModeDefence
+
+	n, err = insertMatchScout(context.Background(), s.sdb, ms)
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(0), n)
+
+	_, err = RespondMatchScoutAssignment(context.Background(), s.sdb, "o1", a.ID, m.UUID, false)
+	s.Require().NoError(err)
+
+	n, err = insertMatchScout(context.Background(), s.sdb, ms)
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(1), n)
+}
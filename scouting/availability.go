@@ -0,0 +1,154 @@
+package scouting
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// Availability is a window in which an account can scout. StartsAt is
+// inclusive and EndsAt exclusive.
+type Availability struct {
+	UUID           uuid.UUID `db:"availability.uuid"`
+	OrganizationID string    `db:"availability.organization_id"`
+	AccountID      string    `db:"availability.account_id"`
+	StartsAt       time.Time `db:"availability.starts_at"`
+	EndsAt         time.Time `db:"availability.ends_at"`
+	CreatedAt      time.Time `db:"availability.created_at"`
+}
+
+func (a *Availability) covers(from, to time.Time) bool {
+	return !a.StartsAt.After(from) && !a.EndsAt.Before(to)
+}
+
+// mergeAvailabilities joins overlapping and adjacent windows of each account
+// so that back to back windows cover a match spanning both.
+func mergeAvailabilities(aa []Availability) []Availability {
+	sorted := slices.Clone(aa)
+
+	slices.SortFunc(sorted, func(a, b Availability) int {
+		return cmp.Or(strings.Compare(a.AccountID, b.AccountID), a.StartsAt.Compare(b.StartsAt))
+	})
+
+	var merged []Availability
+
+	for _, a := range sorted {
+		if n := len(merged); n > 0 && merged[n-1].AccountID == a.AccountID && !a.StartsAt.After(merged[n-1].EndsAt) {
+			if a.EndsAt.After(merged[n-1].EndsAt) {
+				merged[n-1].EndsAt = a.EndsAt
+			}
+
+			continue
+		}
+
+		merged = append(merged, a)
+	}
+
+	return merged
+}
+
+// availableFor reports whether merged windows of the account cover the
+// scouting window of the match.
+func availableFor(aa []Availability, aid string, m Match) bool {
+	for _, a := range aa {
+		if a.AccountID == aid && a.covers(m.StartsAt, m.StartsAt.Add(scoutingWindow)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type NewAvailability struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+func (na *NewAvailability) Validate() error {
+	if na.StartsAt.IsZero() || na.EndsAt.IsZero() {
+		return errors.New("starts at and ends at are required")
+	}
+
+	if !na.EndsAt.After(na.StartsAt) {
+		return errors.New("ends at must be after starts at")
+	}
+
+	return nil
+}
+
+// AvailabilityFilter selects availabilities overlapping From and To, ordered
+// by start. When Limit is set, availabilities are ordered by their time
+// ordered uuid and start after the After uuid.
+type AvailabilityFilter struct {
+	UUID           uuid.UUID
+	OrganizationID string
+	AccountID      string
+	From           time.Time
+	To             time.Time
+	After          uuid.UUID
+	Limit          uint64
+}
+
+func CreateAvailability(ctx context.Context, sdb *sqlx.DB, oid, aid string, na NewAvailability) (Availability, error) {
+	if err := na.Validate(); err != nil {
+		return Availability{}, sbd.NewValidationError(err.Error())
+	}
+
+	a := Availability{
+		UUID:           uuid.Must(uuid.NewV7()),
+		OrganizationID: oid,
+		AccountID:      aid,
+		StartsAt:       na.StartsAt,
+		EndsAt:         na.EndsAt,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := insertAvailability(ctx, sdb, a); err != nil {
+		slog.Error("inserting availability", slog.Any("error", err), slog.String("organization_id", oid), slog.String("account_id", aid))
+
+		return Availability{}, errInternal
+	}
+
+	return a, nil
+}
+
+// DeleteAvailability removes an availability window of the account.
+func DeleteAvailability(ctx context.Context, sdb *sqlx.DB, oid, aid string, availabilityUUID uuid.UUID) error {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+		slog.String("availability_uuid", availabilityUUID.String()),
+	)
+
+	aa, err := SelectAvailabilities(ctx, sdb, AvailabilityFilter{
+		UUID:           availabilityUUID,
+		OrganizationID: oid,
+		AccountID:      aid,
+	})
+	switch {
+	case err == nil && len(aa) > 0:
+		// OK.
+	case err == nil && len(aa) == 0:
+		return sbd.NewNotFoundError("availability")
+	default:
+		logger.Error("selecting availabilities", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	if err = deleteAvailability(ctx, sdb, availabilityUUID); err != nil {
+		logger.Error("deleting availability", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package scouting
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+type BoxScore struct {
+	Attack  BoxScoreLine
+	Defence BoxScoreLine
+}
+
+type BoxScoreLine struct {
+	Possessions       uint
+	Points            uint
+	Shots             uint
+	FreeThrowAttempts uint
+	StatisticTags     map[string]uint
+}
+
+func (bl *BoxScoreLine) add(o Outcome) {
+	bl.Possessions++
+	bl.Points += o.Points
+	bl.FreeThrowAttempts += o.PossibleFreeThrows
+
+	if o.EndedInShot {
+		bl.Shots++
+	}
+
+	for _, t := range o.StatisticTags {
+		bl.StatisticTags[t]++
+	}
+}
+
+func newBoxScore() BoxScore {
+	return BoxScore{
+		Attack: BoxScoreLine{
+			StatisticTags: make(map[string]uint),
+		},
+		Defence: BoxScoreLine{
+			StatisticTags: make(map[string]uint),
+		},
+	}
+}
+
+// countedPossessions returns possessions of finished scouts that should be
+// counted towards match totals. Rules and plays scouts may record the same
+// possession, so for each side rules scouts take precedence and plays scouts
+// are only used when no rules scout covered that side.
+func countedPossessions(mss []MatchScout, pp []Possession) []Possession {
+	scouts := make(map[scoutKey]MatchScout, len(mss))
+	rulesCovered := make(map[uuid.UUID]map[Mode]bool)
+
+	for _, ms := range mss {
+		if !ms.FinishedAt.Valid {
+			continue
+		}
+
+		scouts[scoutKey{ms.MatchUUID, ms.AccountID}] = ms
+
+		if ms.Submode == SubmodePlays {
+			continue
+		}
+
+		covered, ok := rulesCovered[ms.MatchUUID]
+		if !ok {
+			covered = make(map[Mode]bool)
+			rulesCovered[ms.MatchUUID] = covered
+		}
+
+		switch ms.Mode {
+		case ModeAttackDefence:
+			covered[ModeAttack] = true
+			covered[ModeDefence] = true
+		default:
+			covered[ms.Mode] = true
+		}
+	}
+
+	var counted []Possession
+
+	for _, p := range pp {
+		ms, ok := scouts[scoutKey{p.MatchUUID, p.AccountID}]
+		if !ok {
+			continue
+		}
+
+		if ms.Submode == SubmodePlays && rulesCovered[p.MatchUUID][p.Mode] {
+			continue
+		}
+
+		counted = append(counted, p)
+	}
+
+	return counted
+}
+
+func computeBoxScore(cs configSet, mss []MatchScout, pp []Possession) BoxScore {
+	bs := newBoxScore()
+
+	for _, p := range countedPossessions(mss, pp) {
+		o, ok := cs.outcome(p)
+		if !ok {
+			continue
+		}
+
+		switch p.Mode {
+		case ModeAttack:
+			bs.Attack.add(o)
+		case ModeDefence:
+			bs.Defence.add(o)
+		}
+	}
+
+	return bs
+}
+
+// MatchBoxScore aggregates possessions of all finished scout reports of the
+// match.
+func MatchBoxScore(ctx context.Context, sdb *sqlx.DB, oid string, matchUUID uuid.UUID) (BoxScore, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		UUID:           matchUUID,
+		AnyState:       true,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return BoxScore{}, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return BoxScore{}, errInternal
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, MatchScoutFilter{
+		MatchUUID:           &matchUUID,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return BoxScore{}, errInternal
+	}
+
+	pp, err := SelectPossessions(ctx, sdb, PossessionFilter{
+		MatchUUID:           &matchUUID,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return BoxScore{}, errInternal
+	}
+
+	vv, err := selectScoutingConfigVersions(ctx, sdb, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting scouting config versions", slog.Any("error", err))
+
+		return BoxScore{}, errInternal
+	}
+
+	return computeBoxScore(newConfigSet(vv, mss), mss, pp), nil
+}
@@ -0,0 +1,65 @@
+package scouting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeBoxScore(t *testing.T) {
+	t.Parallel()
+
+	matchUUID := uuid.Must(uuid.NewV7())
+	finished := null.NewValue(time.Now(), true)
+
+	mss := []MatchScout{
+		{MatchUUID: matchUUID, AccountID: "rules", Mode: ModeAttack, Submode: SubmodeAnyRules, FinishedAt: finished},
+		{MatchUUID: matchUUID, AccountID: "attack_plays", Mode: ModeAttack, Submode: SubmodePlays, FinishedAt: finished},
+		{MatchUUID: matchUUID, AccountID: "defence_plays", Mode: ModeDefence, Submode: SubmodePlays, FinishedAt: finished},
+		{MatchUUID: matchUUID, AccountID: "unfinished", Mode: ModeDefence, Submode: SubmodeAnyRules},
+	}
+
+	possession := func(aid string, mode Mode, outcome string) Possession {
+		return Possession{MatchUUID: matchUUID, AccountID: aid, Mode: mode, OutcomeID: outcome}
+	}
+
+	pp := []Possession{
+		possession("rules", ModeAttack, "o2"),
+		possession("rules", ModeAttack, "o3 + foul"),
+		possession("rules", ModeAttack, "steal / to"),
+		possession("attack_plays", ModeAttack, "o3"),
+		possession("defence_plays", ModeDefence, "x2 + foul"),
+		possession("defence_plays", ModeDefence, "smart foul"),
+		possession("unfinished", ModeDefence, "o3"),
+	}
+
+	bs := computeBoxScore(singleConfigSet(DefaultScoutingConfig, mss), mss, pp)
+
+	assert.Equal(t, BoxScoreLine{
+		Possessions:       3,
+		Points:            5,
+		Shots:             2,
+		FreeThrowAttempts: 1,
+		StatisticTags: map[string]uint{
+			"foul":                   1,
+			"and one shooting foul":  1,
+			"shooting foul":          1,
+			"three point shoot foul": 1,
+		},
+	}, bs.Attack)
+
+	assert.Equal(t, BoxScoreLine{
+		Possessions:       2,
+		Points:            0,
+		Shots:             1,
+		FreeThrowAttempts: 2,
+		StatisticTags: map[string]uint{
+			"foul":          2,
+			"shooting foul": 1,
+			"smart foul":    1,
+		},
+	}, bs.Defence)
+}
@@ -0,0 +1,221 @@
+package scouting
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// CalendarToken grants read access to calendar feeds of an organization
+// account. Calendar apps cannot send session tokens, so feeds are
+// authenticated by a secret in their URL. Only its hash is stored.
+type CalendarToken struct {
+	TokenHash      string    `db:"calendar_token.token_hash"`
+	OrganizationID string    `db:"calendar_token.organization_id"`
+	AccountID      string    `db:"calendar_token.account_id"`
+	CreatedAt      time.Time `db:"calendar_token.created_at"`
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// CalendarFilter narrows down calendar events. AccountID only returns
+// matches claimed by or pending assignment to the account.
+type CalendarFilter struct {
+	LeagueUUID uuid.UUID
+	AccountID  string
+}
+
+type CalendarScout struct {
+	MatchScout
+	Account Account
+}
+
+// CalendarEvent is an upcoming match with its teams and claimed scouting
+// slots.
+type CalendarEvent struct {
+	Match    Match
+	HomeTeam Team
+	AwayTeam Team
+	Scouts   []CalendarScout
+}
+
+// CreateCalendarToken issues a new calendar token for the account,
+// replacing any previous one.
+func CreateCalendarToken(ctx context.Context, sdb *sqlx.DB, oid, aid string) (string, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+	)
+
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		logger.Error("generating calendar token", slog.Any("error", err))
+
+		return "", errInternal
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	err := upsertCalendarToken(ctx, sdb, CalendarToken{
+		TokenHash:      hashCalendarToken(token),
+		OrganizationID: oid,
+		AccountID:      aid,
+		CreatedAt:      time.Now(),
+	})
+	if err != nil {
+		logger.Error("upserting calendar token", slog.Any("error", err))
+
+		return "", errInternal
+	}
+
+	return token, nil
+}
+
+// DeleteCalendarToken revokes the calendar token of the account.
+func DeleteCalendarToken(ctx context.Context, sdb *sqlx.DB, oid, aid string) error {
+	if err := deleteCalendarToken(ctx, sdb, oid, aid); err != nil {
+		slog.Error("deleting calendar token", slog.Any("error", err), slog.String("organization_id", oid), slog.String("account_id", aid))
+
+		return errInternal
+	}
+
+	return nil
+}
+
+// SelectCalendarToken returns the calendar token matching the secret.
+func SelectCalendarToken(ctx context.Context, sdb *sqlx.DB, token string) (CalendarToken, error) {
+	ct, err := selectCalendarTokens(ctx, sdb, hashCalendarToken(token))
+	switch {
+	case err == nil && len(ct) > 0:
+		return ct[0], nil
+	case err == nil && len(ct) == 0:
+		return CalendarToken{}, sbd.NewNotFoundError("calendar")
+	default:
+		slog.Error("selecting calendar tokens", slog.Any("error", err))
+
+		return CalendarToken{}, errInternal
+	}
+}
+
+// SelectCalendarEvents returns active matches of the organization ordered by
+// start time.
+func SelectCalendarEvents(ctx context.Context, sdb *sqlx.DB, oid string, f CalendarFilter) ([]CalendarEvent, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		Active:         true,
+		LeagueUUID:     f.LeagueUUID,
+		ScoutAccountID: f.AccountID,
+		OrganizationID: oid,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	if len(mm) == 0 {
+		return []CalendarEvent{}, nil
+	}
+
+	slices.SortFunc(mm, func(a, b Match) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	muuids := make([]uuid.UUID, len(mm))
+	tuuids := make([]uuid.UUID, 0, len(mm)*2)
+
+	for i, m := range mm {
+		muuids[i] = m.UUID
+		tuuids = append(tuuids, m.HomeTeamUUID, m.AwayTeamUUID)
+	}
+
+	tt, err := SelectTeams(ctx, sdb, TeamFilter{
+		UUIDs:           tuuids,
+		IncludeArchived: true,
+	})
+	if err != nil {
+		logger.Error("selecting teams", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	teams := make(map[uuid.UUID]Team, len(tt))
+
+	for _, t := range tt {
+		teams[t.UUID] = t
+	}
+
+	msf := MatchScoutFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+		Statuses:            claimingMatchScoutStatuses,
+	}
+
+	if f.AccountID != "" {
+		msf.AccountID = &f.AccountID
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, msf)
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	aa, err := SelectAccounts(ctx, sdb, AccountFilter{
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting accounts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	accounts := make(map[string]Account, len(aa))
+
+	for _, a := range aa {
+		accounts[a.ID] = a
+	}
+
+	scouts := make(map[uuid.UUID][]CalendarScout, len(mm))
+
+	for _, ms := range mss {
+		a, ok := accounts[ms.AccountID]
+		if !ok {
+			a = Account{ID: ms.AccountID}
+		}
+
+		scouts[ms.MatchUUID] = append(scouts[ms.MatchUUID], CalendarScout{
+			MatchScout: ms,
+			Account:    a,
+		})
+	}
+
+	ee := make([]CalendarEvent, len(mm))
+
+	for i, m := range mm {
+		ee[i] = CalendarEvent{
+			Match:    m,
+			HomeTeam: teams[m.HomeTeamUUID],
+			AwayTeam: teams[m.AwayTeamUUID],
+			Scouts:   scouts[m.UUID],
+		}
+	}
+
+	return ee, nil
+}
@@ -0,0 +1,106 @@
+package scouting
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+func (s *Suite) Test_CalendarEvents() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	b := s.createAccount("o1", "2")
+
+	home, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "home",
+	})
+	s.Require().NoError(err)
+
+	away, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "away",
+	})
+	s.Require().NoError(err)
+
+	l, _, err := CreateLeague(context.Background(), s.sdb, "o1", NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
+	s.Require().NoError(err)
+
+	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	nm := NewMatch{
+		LeagueUUID:   l.UUID,
+		HomeTeamUUID: home.UUID,
+		AwayTeamUUID: away.UUID,
+		StartsAt:     time.Now().Add(2 * time.Hour),
+	}
+
+	later, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", nm)
+	s.Require().NoError(err)
+
+	nm.StartsAt = time.Now().Add(time.Hour)
+
+	sooner, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", nm)
+	s.Require().NoError(err)
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, later.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", b.ID, later.UUID, NewMatchScout{
+		Mode:    ModeDefence,
+		Submode: SubmodePlays,
+	})
+	s.Require().NoError(err)
+
+	token, err := CreateCalendarToken(context.Background(), s.sdb, "o1", a.ID)
+	s.Require().NoError(err)
+
+	ct, err := SelectCalendarToken(context.Background(), s.sdb, token)
+	s.Require().NoError(err)
+	s.Assert().Equal(a.ID, ct.AccountID)
+	s.Assert().NotEqual(token, ct.TokenHash)
+
+	s.Run("organization feed", func() {
+		ee, err := SelectCalendarEvents(context.Background(), s.sdb, "o1", CalendarFilter{
+			LeagueUUID: l.UUID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(ee, 2)
+
+		s.Assert().Equal(sooner.UUID, ee[0].Match.UUID)
+		s.Assert().Equal("home", ee[0].HomeTeam.Name)
+		s.Assert().Empty(ee[0].Scouts)
+		s.Assert().Equal(later.UUID, ee[1].Match.UUID)
+		s.Assert().Len(ee[1].Scouts, 2)
+	})
+
+	s.Run("scout feed", func() {
+		ee, err := SelectCalendarEvents(context.Background(), s.sdb, "o1", CalendarFilter{
+			AccountID: a.ID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(ee, 1)
+
+		s.Assert().Equal(later.UUID, ee[0].Match.UUID)
+		s.Require().Len(ee[0].Scouts, 1)
+		s.Assert().Equal(ModeAttack, ee[0].Scouts[0].Mode)
+		s.Assert().Equal(a.ID, ee[0].Scouts[0].Account.ID)
+	})
+
+	s.Run("reissued token revokes the previous one", func() {
+		_, err := CreateCalendarToken(context.Background(), s.sdb, "o1", a.ID)
+		s.Require().NoError(err)
+
+		_, err = SelectCalendarToken(context.Background(), s.sdb, token)
+		s.Assert().Equal(sbd.NewNotFoundError("calendar"), err)
+	})
+}
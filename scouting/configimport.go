@@ -0,0 +1,182 @@
+package scouting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+	"gopkg.in/yaml.v2"
+)
+
+type ConfigFormat string
+
+const (
+	ConfigFormatYAML ConfigFormat = "yaml"
+	ConfigFormatJSON ConfigFormat = "json"
+)
+
+func (cf ConfigFormat) Validate() error {
+	switch cf {
+	case ConfigFormatYAML, ConfigFormatJSON:
+		return nil
+	default:
+		return sbd.NewValidationError("format must be yaml or json")
+	}
+}
+
+// MarshalScoutingConfig encodes the config so that it can be kept under
+// version control and imported again.
+func MarshalScoutingConfig(cfg ScoutingConfig, format ConfigFormat) ([]byte, error) {
+	switch format {
+	case ConfigFormatYAML:
+		return yaml.Marshal(cfg)
+	case ConfigFormatJSON:
+		return json.MarshalIndent(cfg, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+}
+
+// UnmarshalScoutingConfig decodes the config, rejecting unknown fields so
+// that typos are not silently dropped.
+func UnmarshalScoutingConfig(data []byte, format ConfigFormat) (ScoutingConfig, error) {
+	var (
+		cfg ScoutingConfig
+		err error
+	)
+
+	switch format {
+	case ConfigFormatYAML:
+		err = yaml.UnmarshalStrict(data, &cfg)
+	case ConfigFormatJSON:
+		err = unmarshalStrictJSON(data, &cfg)
+	default:
+		err = fmt.Errorf("unsupported config format %q", format)
+	}
+
+	if err != nil {
+		return ScoutingConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+// unmarshalStrictJSON is json.Unmarshal that also rejects unknown fields.
+func unmarshalStrictJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if dec.Decode(&json.RawMessage{}) != io.EOF {
+		return errors.New("unexpected data after config")
+	}
+
+	return nil
+}
+
+// ExportScoutingConfig encodes the current scouting config of the
+// organization.
+func ExportScoutingConfig(ctx context.Context, sdb *sqlx.DB, oid string, format ConfigFormat) ([]byte, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+
+	oo, err := selectOrganizations(ctx, sdb, OrganizationFilter{
+		IDs: []string{oid},
+	}, false)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return nil, sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	data, err := MarshalScoutingConfig(oo[0].ScoutingConfig, format)
+	if err != nil {
+		logger.Error("marshaling scouting config", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return data, nil
+}
+
+// ScoutingConfigImport previews or reports the result of an import. Version
+// is the organization config version after the import.
+type ScoutingConfigImport struct {
+	Problems []ConfigProblem
+	Changes  []ConfigChange
+	Applied  bool
+	Version  uint
+}
+
+// ImportScoutingConfig replaces the organization scouting config with the
+// encoded one. With dry run, or when the config has problems or no changes,
+// nothing is stored and the import only previews problems and changes.
+func ImportScoutingConfig(ctx context.Context, sdb *sqlx.DB, oid string, data []byte, format ConfigFormat, dryRun bool) (ScoutingConfigImport, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	if err := format.Validate(); err != nil {
+		return ScoutingConfigImport{}, err
+	}
+
+	cfg, err := UnmarshalScoutingConfig(data, format)
+	if err != nil {
+		return ScoutingConfigImport{}, sbd.NewValidationError(fmt.Sprintf("invalid %s: %v", format, err))
+	}
+
+	oo, err := selectOrganizations(ctx, sdb, OrganizationFilter{
+		IDs: []string{oid},
+	}, false)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return ScoutingConfigImport{}, sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return ScoutingConfigImport{}, errInternal
+	}
+
+	ci := ScoutingConfigImport{
+		Problems: cfg.Problems(),
+		Changes:  diffScoutingConfigs(oo[0].ScoutingConfig, cfg),
+		Version:  oo[0].ScoutingConfigVersion,
+	}
+
+	if dryRun || len(ci.Problems) > 0 || len(ci.Changes) == 0 {
+		return ci, nil
+	}
+
+	o, err := changeScoutingConfig(ctx, sdb, oid, func(_ *sqlx.Tx, curr *ScoutingConfig) error {
+		// The config may have changed since the preview.
+		ci.Changes = diffScoutingConfigs(*curr, cfg)
+		*curr = cfg
+
+		return nil
+	})
+	if err != nil {
+		return ScoutingConfigImport{}, err
+	}
+
+	ci.Applied = true
+	ci.Version = o.ScoutingConfigVersion
+
+	return ci, nil
+}
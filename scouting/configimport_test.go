@@ -0,0 +1,103 @@
+package scouting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MarshalScoutingConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []ConfigFormat{ConfigFormatYAML, ConfigFormatJSON} {
+		t.Run(string(f), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := MarshalScoutingConfig(DefaultScoutingConfig, f)
+			assert.NoError(t, err)
+
+			cfg, err := UnmarshalScoutingConfig(data, f)
+			assert.NoError(t, err)
+
+			assert.Equal(t, DefaultScoutingConfig, cfg)
+		})
+	}
+}
+
+func Test_UnmarshalScoutingConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := UnmarshalScoutingConfig([]byte("actions:\n  - id: w5\n"), ConfigFormatYAML)
+	assert.NoError(t, err)
+	assert.Equal(t, ScoutingConfig{Actions: []Action{{ID: "w5"}}}, cfg)
+
+	_, err = UnmarshalScoutingConfig([]byte("actions:\n  - id: w5\n    option: []\n"), ConfigFormatYAML)
+	assert.Error(t, err)
+
+	cfg, err = UnmarshalScoutingConfig([]byte(`{"actions": [{"id": "w5"}]}`), ConfigFormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, ScoutingConfig{Actions: []Action{{ID: "w5"}}}, cfg)
+
+	_, err = UnmarshalScoutingConfig([]byte(`{"actions": [{"id": "w5", "option": []}]}`), ConfigFormatJSON)
+	assert.EqualError(t, err, `json: unknown field "option"`)
+
+	_, err = UnmarshalScoutingConfig([]byte(`{"actions": []} {}`), ConfigFormatJSON)
+	assert.EqualError(t, err, "unexpected data after config")
+
+	_, err = UnmarshalScoutingConfig([]byte("{"), ConfigFormatJSON)
+	assert.Error(t, err)
+
+	_, err = UnmarshalScoutingConfig(nil, ConfigFormat("xml"))
+	assert.Error(t, err)
+}
+
+func (s *Suite) Test_ImportScoutingConfig() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	data := []byte(`
+actions:
+  - id: w5
+outcomes:
+  - id: o2
+    points: 2
+    ended_in_shot: true
+layouts:
+  - name: Basic
+    actions:
+      - w5
+`)
+
+	ci, err := ImportScoutingConfig(context.Background(), s.sdb, "o1", data, ConfigFormatYAML, true)
+	s.Require().NoError(err)
+	s.Assert().False(ci.Applied)
+	s.Assert().Empty(ci.Problems)
+	s.Assert().NotEmpty(ci.Changes)
+	s.Assert().Equal(uint(1), ci.Version)
+
+	ci, err = ImportScoutingConfig(context.Background(), s.sdb, "o1", []byte("layouts:\n  - name: Basic\n    actions: [tw5]\n"), ConfigFormatYAML, false)
+	s.Require().NoError(err)
+	s.Assert().False(ci.Applied)
+	s.Assert().Equal([]ConfigProblem{
+		{Path: "layouts[0].actions[0]", Message: `unknown action "tw5"`},
+	}, ci.Problems)
+
+	ci, err = ImportScoutingConfig(context.Background(), s.sdb, "o1", data, ConfigFormatYAML, false)
+	s.Require().NoError(err)
+	s.Assert().True(ci.Applied)
+	s.Assert().Equal(uint(2), ci.Version)
+
+	exported, err := ExportScoutingConfig(context.Background(), s.sdb, "o1", ConfigFormatJSON)
+	s.Require().NoError(err)
+
+	cfg, err := UnmarshalScoutingConfig(exported, ConfigFormatJSON)
+	s.Require().NoError(err)
+	s.Assert().Equal([]Layout{{Name: "Basic", Actions: []string{"w5"}}}, cfg.Layouts)
+
+	ci, err = ImportScoutingConfig(context.Background(), s.sdb, "o1", exported, ConfigFormatJSON, false)
+	s.Require().NoError(err)
+	s.Assert().False(ci.Applied)
+	s.Assert().Empty(ci.Changes)
+	s.Assert().Equal(uint(2), ci.Version)
+}
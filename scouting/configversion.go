@@ -0,0 +1,241 @@
+package scouting
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// ScoutingConfigVersion is an immutable snapshot of an organization scouting
+// config. A new version is stored on every config change and match scouts
+// pin the version that was active when they claimed the match.
+type ScoutingConfigVersion struct {
+	OrganizationID string         `db:"scouting_config_version.organization_id"`
+	Version        uint           `db:"scouting_config_version.version"`
+	ScoutingConfig ScoutingConfig `db:"scouting_config_version.scouting_config"`
+	CreatedAt      time.Time      `db:"scouting_config_version.created_at"`
+}
+
+// ScoutingConfigVersionFilter selects config versions of an organization,
+// newest first. Before returns versions older than the given one.
+type ScoutingConfigVersionFilter struct {
+	OrganizationID string
+	Versions       []uint
+	Before         uint
+	Limit          uint64
+}
+
+type scoutKey struct {
+	matchUUID uuid.UUID
+	accountID string
+}
+
+// configSet resolves possessions against the scouting config version pinned
+// by the match scout that recorded them.
+type configSet struct {
+	versions map[uint]ScoutingConfig
+	scouts   map[scoutKey]uint
+}
+
+func newConfigSet(vv []ScoutingConfigVersion, mss []MatchScout) configSet {
+	cs := configSet{
+		versions: make(map[uint]ScoutingConfig, len(vv)),
+		scouts:   make(map[scoutKey]uint, len(mss)),
+	}
+
+	for _, v := range vv {
+		cs.versions[v.Version] = v.ScoutingConfig
+	}
+
+	for _, ms := range mss {
+		cs.scouts[scoutKey{ms.MatchUUID, ms.AccountID}] = ms.ConfigVersion
+	}
+
+	return cs
+}
+
+func (cs configSet) outcome(p Possession) (Outcome, bool) {
+	v, ok := cs.scouts[scoutKey{p.MatchUUID, p.AccountID}]
+	if !ok {
+		return Outcome{}, false
+	}
+
+	cfg, ok := cs.versions[v]
+	if !ok {
+		return Outcome{}, false
+	}
+
+	return cfg.outcome(p.OutcomeID)
+}
+
+// actions returns actions of the latest version followed by actions and
+// options only defined by older versions.
+func (cs configSet) actions() []Action {
+	vs := make([]uint, 0, len(cs.versions))
+
+	for v := range cs.versions {
+		vs = append(vs, v)
+	}
+
+	slices.SortFunc(vs, func(a, b uint) int {
+		return cmp.Compare(b, a)
+	})
+
+	var aa []Action
+
+	idx := make(map[string]int)
+
+	for _, v := range vs {
+		for _, a := range cs.versions[v].Actions {
+			i, ok := idx[a.ID]
+			if !ok {
+				idx[a.ID] = len(aa)
+				aa = append(aa, Action{ID: a.ID, Options: slices.Clone(a.Options)})
+
+				continue
+			}
+
+			for _, o := range a.Options {
+				if !aa[i].hasOption(o.ID) {
+					aa[i].Options = append(aa[i].Options, o)
+				}
+			}
+		}
+	}
+
+	return aa
+}
+
+type ConfigChangeKind string
+
+const (
+	ConfigChangeAdded   ConfigChangeKind = "added"
+	ConfigChangeRemoved ConfigChangeKind = "removed"
+	ConfigChangeChanged ConfigChangeKind = "changed"
+)
+
+// ConfigChange describes a single difference between two config versions.
+// Entity is one of action, option, outcome or layout. Options are identified
+// as "<action id>/<option id>".
+type ConfigChange struct {
+	Entity string
+	ID     string
+	Kind   ConfigChangeKind
+}
+
+type ScoutingConfigDiff struct {
+	From    uint
+	To      uint
+	Changes []ConfigChange
+}
+
+func diffEntities[T any](entity string, from, to []T, id func(T) string) []ConfigChange {
+	var cc []ConfigChange
+
+	prev := make(map[string]T, len(from))
+
+	for _, e := range from {
+		prev[id(e)] = e
+	}
+
+	next := make(map[string]struct{}, len(to))
+
+	for _, e := range to {
+		next[id(e)] = struct{}{}
+
+		p, ok := prev[id(e)]
+		switch {
+		case !ok:
+			cc = append(cc, ConfigChange{Entity: entity, ID: id(e), Kind: ConfigChangeAdded})
+		case !reflect.DeepEqual(p, e):
+			cc = append(cc, ConfigChange{Entity: entity, ID: id(e), Kind: ConfigChangeChanged})
+		}
+	}
+
+	for _, e := range from {
+		if _, ok := next[id(e)]; !ok {
+			cc = append(cc, ConfigChange{Entity: entity, ID: id(e), Kind: ConfigChangeRemoved})
+		}
+	}
+
+	return cc
+}
+
+func diffScoutingConfigs(from, to ScoutingConfig) []ConfigChange {
+	var cc []ConfigChange
+
+	actionID := func(a Action) string { return a.ID }
+
+	for _, c := range diffEntities("action", from.Actions, to.Actions, actionID) {
+		if c.Kind != ConfigChangeChanged {
+			cc = append(cc, c)
+
+			continue
+		}
+
+		// Actions only hold options, so report which options changed.
+		prev, _ := from.action(c.ID)
+		next, _ := to.action(c.ID)
+
+		optionID := func(o ActionOption) string { return c.ID + "/" + o.ID }
+
+		cc = append(cc, diffEntities("option", prev.Options, next.Options, optionID)...)
+	}
+
+	cc = append(cc, diffEntities("outcome", from.Outcomes, to.Outcomes, func(o Outcome) string { return o.ID })...)
+	cc = append(cc, diffEntities("layout", from.Layouts, to.Layouts, func(l Layout) string { return l.Name })...)
+
+	return cc
+}
+
+// SelectScoutingConfigVersions returns config versions of the organization,
+// newest first.
+func SelectScoutingConfigVersions(ctx context.Context, sdb *sqlx.DB, f ScoutingConfigVersionFilter) ([]ScoutingConfigVersion, error) {
+	vv, err := selectScoutingConfigVersions(ctx, sdb, f)
+	if err != nil {
+		slog.Error("selecting scouting config versions", slog.Any("error", err), slog.String("organization_id", f.OrganizationID))
+
+		return nil, errInternal
+	}
+
+	return vv, nil
+}
+
+// DiffScoutingConfigVersions lists changes needed to get from one config
+// version to another.
+func DiffScoutingConfigVersions(ctx context.Context, sdb *sqlx.DB, oid string, from, to uint) (ScoutingConfigDiff, error) {
+	vv, err := selectScoutingConfigVersions(ctx, sdb, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+		Versions:       []uint{from, to},
+	})
+	if err != nil {
+		slog.Error("selecting scouting config versions", slog.Any("error", err), slog.String("organization_id", oid))
+
+		return ScoutingConfigDiff{}, errInternal
+	}
+
+	cs := newConfigSet(vv, nil)
+
+	fcfg, ok := cs.versions[from]
+	if !ok {
+		return ScoutingConfigDiff{}, sbd.NewNotFoundError("scouting config version")
+	}
+
+	tcfg, ok := cs.versions[to]
+	if !ok {
+		return ScoutingConfigDiff{}, sbd.NewNotFoundError("scouting config version")
+	}
+
+	return ScoutingConfigDiff{
+		From:    from,
+		To:      to,
+		Changes: diffScoutingConfigs(fcfg, tcfg),
+	}, nil
+}
@@ -0,0 +1,170 @@
+package scouting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/sportsbydata/backend/sbd"
+	"github.com/stretchr/testify/assert"
+)
+
+// singleConfigSet pins all match scouts to the same config.
+func singleConfigSet(cfg ScoutingConfig, mss []MatchScout) configSet {
+	return newConfigSet([]ScoutingConfigVersion{{ScoutingConfig: cfg}}, mss)
+}
+
+func Test_configSet(t *testing.T) {
+	t.Parallel()
+
+	matchUUID := uuid.Must(uuid.NewV7())
+	finished := null.NewValue(time.Now(), true)
+
+	v1 := ScoutingConfig{
+		Actions:  []Action{{ID: "w5", Options: []ActionOption{{ID: "1"}}}, {ID: "removed"}},
+		Outcomes: []Outcome{{ID: "o2", Points: 2}},
+	}
+
+	v2 := ScoutingConfig{
+		Actions:  []Action{{ID: "w4"}, {ID: "w5", Options: []ActionOption{{ID: "2"}}}},
+		Outcomes: []Outcome{{ID: "o2", Points: 3}},
+	}
+
+	mss := []MatchScout{
+		{MatchUUID: matchUUID, AccountID: "old", Mode: ModeAttack, Submode: SubmodeAnyRules, ConfigVersion: 1, FinishedAt: finished},
+		{MatchUUID: matchUUID, AccountID: "new", Mode: ModeDefence, Submode: SubmodeAnyRules, ConfigVersion: 2, FinishedAt: finished},
+	}
+
+	cs := newConfigSet([]ScoutingConfigVersion{
+		{Version: 2, ScoutingConfig: v2},
+		{Version: 1, ScoutingConfig: v1},
+	}, mss)
+
+	t.Run("outcome resolved against pinned version", func(t *testing.T) {
+		t.Parallel()
+
+		o, ok := cs.outcome(Possession{MatchUUID: matchUUID, AccountID: "old", OutcomeID: "o2"})
+		assert.True(t, ok)
+		assert.Equal(t, uint(2), o.Points)
+
+		o, ok = cs.outcome(Possession{MatchUUID: matchUUID, AccountID: "new", OutcomeID: "o2"})
+		assert.True(t, ok)
+		assert.Equal(t, uint(3), o.Points)
+
+		_, ok = cs.outcome(Possession{MatchUUID: matchUUID, AccountID: "unknown", OutcomeID: "o2"})
+		assert.False(t, ok)
+	})
+
+	t.Run("actions ordered by latest version", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []Action{
+			{ID: "w4"},
+			{ID: "w5", Options: []ActionOption{{ID: "2"}, {ID: "1"}}},
+			{ID: "removed"},
+		}, cs.actions())
+	})
+
+	t.Run("box score", func(t *testing.T) {
+		t.Parallel()
+
+		bs := computeBoxScore(cs, mss, []Possession{
+			{MatchUUID: matchUUID, AccountID: "old", Mode: ModeAttack, OutcomeID: "o2"},
+			{MatchUUID: matchUUID, AccountID: "new", Mode: ModeDefence, OutcomeID: "o2"},
+		})
+
+		assert.Equal(t, uint(2), bs.Attack.Points)
+		assert.Equal(t, uint(3), bs.Defence.Points)
+	})
+}
+
+func Test_diffScoutingConfigs(t *testing.T) {
+	t.Parallel()
+
+	from := ScoutingConfig{
+		Actions: []Action{
+			{ID: "w5", Options: []ActionOption{{ID: "1"}, {ID: "2"}}},
+			{ID: "removed"},
+		},
+		Outcomes: []Outcome{{ID: "o2", Points: 2}, {ID: "o3", Points: 3}},
+		Layouts:  []Layout{{Name: "Basic", Actions: []string{"w5"}}},
+	}
+
+	to := ScoutingConfig{
+		Actions: []Action{
+			{ID: "w5", Options: []ActionOption{{ID: "1"}, {ID: "3"}}},
+			{ID: "added"},
+		},
+		Outcomes: []Outcome{{ID: "o2", Points: 3}, {ID: "o3", Points: 3}},
+		Layouts:  []Layout{{Name: "Basic", Actions: []string{"w5", "added"}}},
+	}
+
+	assert.Equal(t, []ConfigChange{
+		{Entity: "option", ID: "w5/3", Kind: ConfigChangeAdded},
+		{Entity: "option", ID: "w5/2", Kind: ConfigChangeRemoved},
+		{Entity: "action", ID: "added", Kind: ConfigChangeAdded},
+		{Entity: "action", ID: "removed", Kind: ConfigChangeRemoved},
+		{Entity: "outcome", ID: "o2", Kind: ConfigChangeChanged},
+		{Entity: "layout", ID: "Basic", Kind: ConfigChangeChanged},
+	}, diffScoutingConfigs(from, to))
+
+	assert.Empty(t, diffScoutingConfigs(from, from))
+}
+
+func (s *Suite) Test_ScoutingConfigVersions() {
+	o, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+	s.Assert().Equal(uint(1), o.ScoutingConfigVersion)
+
+	a := s.createAccount("o1", "1")
+	m := s.createMatch("o1")
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	cfg := ScoutingConfig{
+		Actions:  []Action{{ID: "w5"}},
+		Outcomes: []Outcome{{ID: "o2", Points: 2, EndedInShot: true}},
+	}
+
+	o, err = UpdateScoutingConfig(context.Background(), s.sdb, "o1", cfg)
+	s.Require().NoError(err)
+	s.Assert().Equal(uint(2), o.ScoutingConfigVersion)
+
+	vv, err := SelectScoutingConfigVersions(context.Background(), s.sdb, ScoutingConfigVersionFilter{
+		OrganizationID: "o1",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(vv, 2)
+	s.Assert().Equal(uint(2), vv[0].Version)
+	s.Assert().Equal(cfg, vv[0].ScoutingConfig)
+	s.Assert().Equal(uint(1), vv[1].Version)
+	s.Assert().Equal(DefaultScoutingConfig, vv[1].ScoutingConfig)
+
+	mss, err := SelectMatchScouts(context.Background(), s.sdb, MatchScoutFilter{
+		MatchUUID: &m.UUID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(mss, 1)
+	s.Assert().Equal(uint(1), mss[0].ConfigVersion)
+
+	// Actions of the pinned version are still accepted.
+	_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 1, ActionID: "w4", OutcomeID: "o3", Mode: ModeAttack, Rule: "r1"},
+	})
+	s.Require().NoError(err)
+
+	d, err := DiffScoutingConfigVersions(context.Background(), s.sdb, "o1", 1, 2)
+	s.Require().NoError(err)
+	s.Assert().Equal(uint(1), d.From)
+	s.Assert().Equal(uint(2), d.To)
+	s.Assert().NotEmpty(d.Changes)
+
+	_, err = DiffScoutingConfigVersions(context.Background(), s.sdb, "o1", 1, 3)
+	s.Assert().Equal(sbd.NewNotFoundError("scouting config version"), err)
+}
@@ -0,0 +1,167 @@
+package scouting
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// ScoutSlot is a mode and submode combination a scout can claim.
+type ScoutSlot struct {
+	Mode    Mode
+	Submode Submode
+}
+
+var (
+	allModes    = []Mode{ModeAttack, ModeDefence, ModeAttackDefence}
+	allSubmodes = []Submode{SubmodeAllRules, SubmodeAnyRules, SubmodeOurRules, SubmodeNotOurRules, SubmodePlays}
+)
+
+// MatchCoverage describes which parts of a match are scouted. Claimable
+// lists combinations that do not conflict with pending or accepted scouts.
+// Full is set once rules and plays are covered for both attack and defence.
+type MatchCoverage struct {
+	Match        Match
+	Claimable    []ScoutSlot
+	AttackRules  bool
+	AttackPlays  bool
+	DefenceRules bool
+	DefencePlays bool
+	Full         bool
+}
+
+type CoverageFilter struct {
+	LeagueUUID uuid.UUID
+}
+
+// modesCovered returns modes a scout of the mode covers.
+func modesCovered(m Mode) []Mode {
+	if m == ModeAttackDefence {
+		return []Mode{ModeAttack, ModeDefence}
+	}
+
+	return []Mode{m}
+}
+
+func computeCoverage(m Match, mss []MatchScout) MatchCoverage {
+	mc := MatchCoverage{
+		Match:     m,
+		Claimable: []ScoutSlot{},
+	}
+
+	for _, mode := range allModes {
+		for _, submode := range allSubmodes {
+			if !modeSubmodeValid(mode, submode) {
+				continue
+			}
+
+			claimable := true
+
+			for _, ms := range mss {
+				if modesSubmodesConflicts(modeSubmode{ms.Mode, ms.Submode}, modeSubmode{mode, submode}) {
+					claimable = false
+
+					break
+				}
+			}
+
+			if claimable {
+				mc.Claimable = append(mc.Claimable, ScoutSlot{Mode: mode, Submode: submode})
+			}
+		}
+	}
+
+	type coverage struct {
+		ours, notOurs, rules, plays bool
+	}
+
+	covered := make(map[Mode]*coverage, 2)
+	covered[ModeAttack] = &coverage{}
+	covered[ModeDefence] = &coverage{}
+
+	for _, ms := range mss {
+		for _, mode := range modesCovered(ms.Mode) {
+			c := covered[mode]
+
+			switch ms.Submode {
+			case SubmodeAllRules, SubmodeAnyRules:
+				c.rules = true
+			case SubmodeOurRules:
+				c.ours = true
+			case SubmodeNotOurRules:
+				c.notOurs = true
+			case SubmodePlays:
+				c.plays = true
+			}
+		}
+	}
+
+	attack, defence := covered[ModeAttack], covered[ModeDefence]
+
+	mc.AttackRules = attack.rules || attack.ours && attack.notOurs
+	mc.AttackPlays = attack.plays
+	mc.DefenceRules = defence.rules || defence.ours && defence.notOurs
+	mc.DefencePlays = defence.plays
+	mc.Full = mc.AttackRules && mc.AttackPlays && mc.DefenceRules && mc.DefencePlays
+
+	return mc
+}
+
+// SelectCoverage returns coverage of active organization matches ordered by
+// start time.
+func SelectCoverage(ctx context.Context, sdb *sqlx.DB, oid string, f CoverageFilter) ([]MatchCoverage, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		Active:         true,
+		LeagueUUID:     f.LeagueUUID,
+		OrganizationID: oid,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	if len(mm) == 0 {
+		return []MatchCoverage{}, nil
+	}
+
+	slices.SortFunc(mm, func(a, b Match) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	muuids := make([]uuid.UUID, len(mm))
+
+	for i, m := range mm {
+		muuids[i] = m.UUID
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, MatchScoutFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+		Statuses:            claimingMatchScoutStatuses,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	scouts := make(map[uuid.UUID][]MatchScout, len(mm))
+
+	for _, ms := range mss {
+		scouts[ms.MatchUUID] = append(scouts[ms.MatchUUID], ms)
+	}
+
+	mcc := make([]MatchCoverage, len(mm))
+
+	for i, m := range mm {
+		mcc[i] = computeCoverage(m, scouts[m.UUID])
+	}
+
+	return mcc, nil
+}
@@ -0,0 +1,79 @@
+package scouting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeCoverage(t *testing.T) {
+	t.Parallel()
+
+	scout := func(m Mode, sm Submode) MatchScout {
+		return MatchScout{Mode: m, Submode: sm}
+	}
+
+	tests := map[string]struct {
+		Scouts    []MatchScout
+		Claimable []ScoutSlot
+		Rules     [2]bool
+		Plays     [2]bool
+		Full      bool
+	}{
+		"attack rules": {
+			Scouts: []MatchScout{
+				scout(ModeAttack, SubmodeAllRules),
+			},
+			Claimable: []ScoutSlot{
+				{ModeAttack, SubmodePlays},
+				{ModeDefence, SubmodeAllRules},
+				{ModeDefence, SubmodeAnyRules},
+				{ModeDefence, SubmodeOurRules},
+				{ModeDefence, SubmodeNotOurRules},
+				{ModeDefence, SubmodePlays},
+				{ModeAttackDefence, SubmodePlays},
+			},
+			Rules: [2]bool{true, false},
+		},
+		"split rules": {
+			Scouts: []MatchScout{
+				scout(ModeAttack, SubmodeOurRules),
+				scout(ModeAttack, SubmodeNotOurRules),
+				scout(ModeAttack, SubmodePlays),
+				scout(ModeDefence, SubmodeAllRules),
+			},
+			Claimable: []ScoutSlot{
+				{ModeDefence, SubmodePlays},
+			},
+			Rules: [2]bool{true, true},
+			Plays: [2]bool{true, false},
+		},
+		"attack and defence": {
+			Scouts: []MatchScout{
+				scout(ModeAttackDefence, SubmodeAnyRules),
+				scout(ModeAttackDefence, SubmodePlays),
+			},
+			Claimable: []ScoutSlot{},
+			Rules:     [2]bool{true, true},
+			Plays:     [2]bool{true, true},
+			Full:      true,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			mc := computeCoverage(Match{}, tc.Scouts)
+
+			assert.Equal(t, tc.Claimable, mc.Claimable)
+			assert.Equal(t, tc.Rules, [2]bool{mc.AttackRules, mc.DefenceRules})
+			assert.Equal(t, tc.Plays, [2]bool{mc.AttackPlays, mc.DefencePlays})
+			assert.Equal(t, tc.Full, mc.Full)
+		})
+	}
+
+	mc := computeCoverage(Match{}, nil)
+	assert.Len(t, mc.Claimable, 12)
+	assert.False(t, mc.Full)
+}
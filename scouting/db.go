@@ -81,6 +81,18 @@ func SelectAccounts(ctx context.Context, qr sqlx.QueryerContext, f AccountFilter
 			Where(squirrel.Eq{"organization_account.organization_id": &f.OrganizationID})
 	}
 
+	if f.ID != "" {
+		sb = sb.Where(squirrel.Eq{"account.id": f.ID})
+	}
+
+	if f.After != "" {
+		sb = sb.Where(squirrel.Gt{"account.id": f.After})
+	}
+
+	if f.Limit > 0 {
+		sb = sb.OrderBy("account.id").Limit(f.Limit)
+	}
+
 	sql, args := sb.MustSql()
 
 	var aa []Account
@@ -134,9 +146,90 @@ func insertLeague(ctx context.Context, ec sqlx.ExecerContext, l League) error {
 	return handleDbError(err)
 }
 
-func insertLeagueTeam(ctx context.Context, ec sqlx.ExecerContext, luuid, tuuid uuid.UUID) error {
-	sb := squirrel.Insert("league_team").SetMap(map[string]any{
-		"league_uuid": luuid,
+func seasonCols() []string {
+	return []string{
+		`season.uuid AS "season.uuid"`,
+		`season.league_uuid AS "season.league_uuid"`,
+		`season.name AS "season.name"`,
+		`season.starts_at AS "season.starts_at"`,
+		`season.ends_at AS "season.ends_at"`,
+		`season.standings_tie_breakers AS "season.standings_tie_breakers"`,
+		`season.created_at AS "season.created_at"`,
+		`season.modified_at AS "season.modified_at"`,
+	}
+}
+
+func SelectSeasons(ctx context.Context, qr sqlx.QueryerContext, f SeasonFilter) ([]Season, error) {
+	sb := squirrel.Select(seasonCols()...).
+		From("season AS season").
+		OrderBy("season.starts_at DESC")
+
+	var dec squirrel.And
+
+	if !f.UUID.IsNil() {
+		dec = append(dec, squirrel.Eq{"season.uuid": f.UUID})
+	}
+
+	if !f.LeagueUUID.IsNil() {
+		dec = append(dec, squirrel.Eq{"season.league_uuid": f.LeagueUUID})
+	}
+
+	if f.OrganizationID != "" {
+		sb = sb.InnerJoin("organization_league ON organization_league.league_uuid=season.league_uuid")
+
+		dec = append(dec, squirrel.Eq{
+			"organization_league.organization_id": f.OrganizationID,
+		})
+	}
+
+	if len(dec) > 0 {
+		sb = sb.Where(dec)
+	}
+
+	sql, args := sb.MustSql()
+
+	var ss []Season
+
+	if err := sqlx.SelectContext(ctx, qr, &ss, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return ss, nil
+}
+
+func insertSeason(ctx context.Context, ec sqlx.ExecerContext, s Season) error {
+	sb := squirrel.Insert("season").SetMap(map[string]any{
+		"uuid":                   s.UUID,
+		"league_uuid":            s.LeagueUUID,
+		"name":                   s.Name,
+		"starts_at":              s.StartsAt,
+		"ends_at":                s.EndsAt,
+		"created_at":             s.CreatedAt,
+		"modified_at":            s.ModifiedAt,
+		"standings_tie_breakers": s.StandingsTieBreakers,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func updateSeason(ctx context.Context, ec sqlx.ExecerContext, s Season) error {
+	sb := squirrel.Update("season").SetMap(map[string]any{
+		"standings_tie_breakers": s.StandingsTieBreakers,
+		"modified_at":            s.ModifiedAt,
+	}).Where(squirrel.Eq{"uuid": s.UUID})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func insertSeasonTeam(ctx context.Context, ec sqlx.ExecerContext, suuid, tuuid uuid.UUID) error {
+	sb := squirrel.Insert("season_team").SetMap(map[string]any{
+		"season_uuid": suuid,
 		"team_uuid":   tuuid,
 	})
 
@@ -146,6 +239,18 @@ func insertLeagueTeam(ctx context.Context, ec sqlx.ExecerContext, luuid, tuuid u
 	return handleDbError(err)
 }
 
+func deleteSeasonTeams(ctx context.Context, ec sqlx.ExecerContext, suuid uuid.UUID, tuuids []uuid.UUID) error {
+	sb := squirrel.Delete("season_team").Where(squirrel.Eq{
+		"season_uuid": suuid,
+		"team_uuid":   tuuids,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
 func leagueCols() []string {
 	return []string{
 		`league.uuid AS "league.uuid"`,
@@ -167,7 +272,7 @@ func insertOrganizationLeague(ctx context.Context, ec sqlx.ExecerContext, oid st
 	return handleDbError(err)
 }
 
-func SelectLeagues(ctx context.Context, qr sqlx.QueryerContext, f LeagueFilter) ([]League, error) {
+func SelectLeagues(ctx context.Context, qr sqlx.QueryerContext, f LeagueFilter, lock bool) ([]League, error) {
 	sb := squirrel.Select(leagueCols()...).From("league AS league")
 
 	var dec squirrel.And
@@ -186,10 +291,22 @@ func SelectLeagues(ctx context.Context, qr sqlx.QueryerContext, f LeagueFilter)
 		})
 	}
 
+	if !f.After.IsNil() {
+		dec = append(dec, squirrel.Gt{"league.uuid": f.After})
+	}
+
 	if len(dec) > 0 {
 		sb = sb.Where(dec)
 	}
 
+	if f.Limit > 0 {
+		sb = sb.OrderBy("league.uuid").Limit(f.Limit)
+	}
+
+	if lock {
+		sb = sb.Suffix("FOR UPDATE")
+	}
+
 	sql, args := sb.MustSql()
 
 	var ll []League
@@ -216,13 +333,16 @@ func matchCols() []string {
 	return []string{
 		`match.uuid AS "match.uuid"`,
 		`match.league_uuid AS "match.league_uuid"`,
+		`match.season_uuid AS "match.season_uuid"`,
 		`match.away_team_uuid AS "match.away_team_uuid"`,
 		`match.home_team_uuid AS "match.home_team_uuid"`,
+		`match.scouted_team_uuid AS "match.scouted_team_uuid"`,
 		`match.created_by AS "match.created_by"`,
 		`match.home_score AS "match.home_score"`,
 		`match.away_score AS "match.away_score"`,
 		`match.organization_id AS "match.organization_id"`,
 		`match.starts_at AS "match.starts_at"`,
+		`match.status AS "match.status"`,
 		`match.finished_at AS "match.finished_at"`,
 		`match.created_at AS "match.created_at"`,
 		`match.modified_at AS "match.modified_at"`,
@@ -236,14 +356,61 @@ func SelectMatches(ctx context.Context, qr sqlx.QueryerContext, f MatchFilter, l
 		dec = append(dec, squirrel.Eq{"match.organization_id": f.OrganizationID})
 	}
 
-	if f.Active {
-		dec = append(dec, squirrel.Expr("match.finished_at IS NULL"))
-	} else {
-		dec = append(dec, squirrel.Expr("match.finished_at IS NOT NULL"))
+	switch {
+	case f.AnyState:
+		// OK.
+	case f.Active:
+		dec = append(dec, squirrel.Eq{"match.status": activeMatchStatuses})
+	default:
+		dec = append(dec, squirrel.Eq{"match.status": MatchStatusFinished})
 	}
 
 	if !f.UUID.IsNil() {
-		dec = append(dec, squirrel.Eq{"uuid": f.UUID})
+		dec = append(dec, squirrel.Eq{"match.uuid": f.UUID})
+	}
+
+	if !f.LeagueUUID.IsNil() {
+		dec = append(dec, squirrel.Eq{"match.league_uuid": f.LeagueUUID})
+	}
+
+	if !f.SeasonUUID.IsNil() {
+		dec = append(dec, squirrel.Eq{"match.season_uuid": f.SeasonUUID})
+	}
+
+	// Seasons of a league don't overlap, so the latest season that started
+	// is the running one or, between seasons, the previous one.
+	if !f.CurrentSeasonAt.IsZero() {
+		dec = append(dec, squirrel.Expr(
+			"match.season_uuid IN (SELECT DISTINCT ON (league_uuid) uuid FROM season "+
+				"WHERE starts_at <= ? ORDER BY league_uuid, starts_at DESC)",
+			f.CurrentSeasonAt,
+		))
+	}
+
+	if !f.TeamUUID.IsNil() {
+		dec = append(dec, squirrel.Or{
+			squirrel.Eq{"match.home_team_uuid": f.TeamUUID},
+			squirrel.Eq{"match.away_team_uuid": f.TeamUUID},
+		})
+	}
+
+	if f.ScoutAccountID != "" {
+		dec = append(dec, squirrel.Expr(
+			"match.uuid IN (SELECT match_uuid FROM match_scout WHERE account_id = ? AND status <> ?)",
+			f.ScoutAccountID, MatchScoutStatusDeclined,
+		))
+	}
+
+	if !f.StartsFrom.IsZero() {
+		dec = append(dec, squirrel.GtOrEq{"match.starts_at": f.StartsFrom})
+	}
+
+	if !f.StartsTo.IsZero() {
+		dec = append(dec, squirrel.Lt{"match.starts_at": f.StartsTo})
+	}
+
+	if !f.After.IsNil() {
+		dec = append(dec, squirrel.Gt{"match.uuid": f.After})
 	}
 
 	sb := squirrel.Select(matchCols()...).From("match AS match")
@@ -252,6 +419,10 @@ func SelectMatches(ctx context.Context, qr sqlx.QueryerContext, f MatchFilter, l
 		sb = sb.Where(dec)
 	}
 
+	if f.Limit > 0 {
+		sb = sb.OrderBy("match.uuid").Limit(f.Limit)
+	}
+
 	if lock {
 		sb = sb.Suffix("FOR UPDATE")
 	}
@@ -269,18 +440,21 @@ func SelectMatches(ctx context.Context, qr sqlx.QueryerContext, f MatchFilter, l
 
 func insertMatch(ctx context.Context, ec sqlx.ExecerContext, m Match) error {
 	sb := squirrel.Insert("match").SetMap(map[string]any{
-		"uuid":            m.UUID,
-		"league_uuid":     m.LeagueUUID,
-		"away_team_uuid":  m.AwayTeamUUID,
-		"home_team_uuid":  m.HomeTeamUUID,
-		"created_by":      m.CreatedBy,
-		"home_score":      m.HomeScore,
-		"away_score":      m.AwayScore,
-		"organization_id": m.OrganizationID,
-		"starts_at":       m.StartsAt,
-		"finished_at":     m.FinishedAt,
-		"created_at":      m.CreatedAt,
-		"modified_at":     m.ModifiedAt,
+		"uuid":              m.UUID,
+		"league_uuid":       m.LeagueUUID,
+		"season_uuid":       m.SeasonUUID,
+		"away_team_uuid":    m.AwayTeamUUID,
+		"home_team_uuid":    m.HomeTeamUUID,
+		"scouted_team_uuid": m.ScoutedTeamUUID,
+		"created_by":        m.CreatedBy,
+		"home_score":        m.HomeScore,
+		"away_score":        m.AwayScore,
+		"organization_id":   m.OrganizationID,
+		"starts_at":         m.StartsAt,
+		"status":            m.Status,
+		"finished_at":       m.FinishedAt,
+		"created_at":        m.CreatedAt,
+		"modified_at":       m.ModifiedAt,
 	})
 
 	sql, args := sb.MustSql()
@@ -293,6 +467,8 @@ func updateMatch(ctx context.Context, ec sqlx.ExecerContext, m Match) error {
 	sb := squirrel.Update("match").SetMap(map[string]any{
 		"home_score":  m.HomeScore,
 		"away_score":  m.AwayScore,
+		"starts_at":   m.StartsAt,
+		"status":      m.Status,
 		"finished_at": m.FinishedAt,
 		"modified_at": m.ModifiedAt,
 	}).Where(squirrel.Eq{
@@ -305,22 +481,48 @@ func updateMatch(ctx context.Context, ec sqlx.ExecerContext, m Match) error {
 	return handleDbError(err)
 }
 
-func insertMatchScout(ctx context.Context, ec sqlx.ExecerContext, ms MatchScout) error {
+// insertMatchScout stores the slot, replacing a declined one of the account,
+// and returns the number of rows written. It is 0 when the account holds a
+// slot that is not declined.
+func insertMatchScout(ctx context.Context, ec sqlx.ExecerContext, ms MatchScout) (int64, error) {
 	sb := squirrel.Insert("match_scout").SetMap(map[string]any{
-		"match_uuid": ms.MatchUUID,
-		"account_id": ms.AccountID,
-		"mode":       ms.Mode,
-		"submode":    ms.Submode,
-	})
+		"match_uuid":     ms.MatchUUID,
+		"account_id":     ms.AccountID,
+		"mode":           ms.Mode,
+		"submode":        ms.Submode,
+		"layout":         ms.Layout,
+		"layout_actions": ms.LayoutActions,
+		"config_version": ms.ConfigVersion,
+		"status":         ms.Status,
+		"assigned_by":    ms.AssignedBy,
+	}).Suffix(`ON CONFLICT (match_uuid, account_id) DO UPDATE SET
+		mode=EXCLUDED.mode,
+		submode=EXCLUDED.submode,
+		layout=EXCLUDED.layout,
+		layout_actions=EXCLUDED.layout_actions,
+		config_version=EXCLUDED.config_version,
+		status=EXCLUDED.status,
+		assigned_by=EXCLUDED.assigned_by
+	WHERE match_scout.status = 'declined'`)
 
 	sql, args := sb.MustSql()
 
-	_, err := ec.ExecContext(ctx, sql, args...)
-	return handleDbError(err)
+	res, err := ec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, handleDbError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, handleDbError(err)
+	}
+
+	return n, nil
 }
 
 func updateMatchScout(ctx context.Context, ec sqlx.ExecerContext, ms MatchScout) error {
 	sb := squirrel.Update("match_scout").SetMap(map[string]any{
+		"status":      ms.Status,
 		"finished_at": ms.FinishedAt,
 	}).Where(squirrel.And{
 		squirrel.Eq{"account_id": ms.AccountID},
@@ -333,12 +535,276 @@ func updateMatchScout(ctx context.Context, ec sqlx.ExecerContext, ms MatchScout)
 	return handleDbError(err)
 }
 
+// deleteMatchScout removes the scouting slot of the account. Possessions
+// recorded for it must be archived first.
+func deleteMatchScout(ctx context.Context, ec sqlx.ExecerContext, muuid uuid.UUID, aid string) error {
+	sql, args := squirrel.Delete("match_scout").Where(squirrel.Eq{
+		"match_uuid": muuid,
+		"account_id": aid,
+	}).MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+// archivePossessions moves the possessions of the account in the match to
+// released_possession under the given audit and returns how many were moved.
+func archivePossessions(ctx context.Context, ec sqlx.ExecerContext, auditUUID, muuid uuid.UUID, aid string) (int64, error) {
+	where := squirrel.Eq{
+		"match_uuid": muuid,
+		"account_id": aid,
+	}
+
+	cols := []string{
+		"uuid",
+		"match_uuid",
+		"account_id",
+		"action_id",
+		"action_option_id",
+		"outcome_id",
+		"sequence",
+		"mode",
+		"play",
+		"rule",
+		"game_time",
+		"created_at",
+	}
+
+	sb := squirrel.Insert("released_possession").
+		Columns(append([]string{"match_scout_audit_uuid"}, cols...)...).
+		Select(squirrel.Select().Column("?::UUID", auditUUID).Columns(cols...).From("possession").Where(where))
+
+	sql, args := sb.MustSql()
+
+	res, err := ec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, handleDbError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, handleDbError(err)
+	}
+
+	sql, args = squirrel.Delete("possession").Where(where).MustSql()
+
+	if _, err = ec.ExecContext(ctx, sql, args...); err != nil {
+		return 0, handleDbError(err)
+	}
+
+	return n, nil
+}
+
+// transferMatchScout moves the scouting slot to another account. Possessions
+// follow through their cascading foreign key.
+func transferMatchScout(ctx context.Context, ec sqlx.ExecerContext, muuid uuid.UUID, from, to string) error {
+	sb := squirrel.Update("match_scout").Set("account_id", to).Where(squirrel.Eq{
+		"match_uuid": muuid,
+		"account_id": from,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func insertMatchScoutAudit(ctx context.Context, ec sqlx.ExecerContext, ma MatchScoutAudit) error {
+	sb := squirrel.Insert("match_scout_audit").SetMap(map[string]any{
+		"uuid":              ma.UUID,
+		"match_uuid":        ma.MatchUUID,
+		"action":            ma.Action,
+		"account_id":        ma.AccountID,
+		"target_account_id": ma.TargetAccountID,
+		"mode":              ma.Mode,
+		"submode":           ma.Submode,
+		"possessions":       ma.Possessions,
+		"performed_by":      ma.PerformedBy,
+		"created_at":        ma.CreatedAt,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func selectMatchScoutAudits(ctx context.Context, qr sqlx.QueryerContext, muuid uuid.UUID) ([]MatchScoutAudit, error) {
+	sb := squirrel.Select(
+		`match_scout_audit.uuid AS "match_scout_audit.uuid"`,
+		`match_scout_audit.match_uuid AS "match_scout_audit.match_uuid"`,
+		`match_scout_audit.action AS "match_scout_audit.action"`,
+		`match_scout_audit.account_id AS "match_scout_audit.account_id"`,
+		`match_scout_audit.target_account_id AS "match_scout_audit.target_account_id"`,
+		`match_scout_audit.mode AS "match_scout_audit.mode"`,
+		`match_scout_audit.submode AS "match_scout_audit.submode"`,
+		`match_scout_audit.possessions AS "match_scout_audit.possessions"`,
+		`match_scout_audit.performed_by AS "match_scout_audit.performed_by"`,
+		`match_scout_audit.created_at AS "match_scout_audit.created_at"`,
+	).From("match_scout_audit AS match_scout_audit").Where(squirrel.Eq{
+		"match_scout_audit.match_uuid": muuid,
+	}).OrderBy("match_scout_audit.created_at ASC")
+
+	sql, args := sb.MustSql()
+
+	var maa []MatchScoutAudit
+
+	if err := sqlx.SelectContext(ctx, qr, &maa, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return maa, nil
+}
+
+func SelectAvailabilities(ctx context.Context, qr sqlx.QueryerContext, f AvailabilityFilter) ([]Availability, error) {
+	var dec squirrel.And
+
+	if !f.UUID.IsNil() {
+		dec = append(dec, squirrel.Eq{"availability.uuid": f.UUID})
+	}
+
+	if f.OrganizationID != "" {
+		dec = append(dec, squirrel.Eq{"availability.organization_id": f.OrganizationID})
+	}
+
+	if f.AccountID != "" {
+		dec = append(dec, squirrel.Eq{"availability.account_id": f.AccountID})
+	}
+
+	if !f.From.IsZero() {
+		dec = append(dec, squirrel.Gt{"availability.ends_at": f.From})
+	}
+
+	if !f.To.IsZero() {
+		dec = append(dec, squirrel.Lt{"availability.starts_at": f.To})
+	}
+
+	if !f.After.IsNil() {
+		dec = append(dec, squirrel.Gt{"availability.uuid": f.After})
+	}
+
+	sb := squirrel.Select(
+		`availability.uuid AS "availability.uuid"`,
+		`availability.organization_id AS "availability.organization_id"`,
+		`availability.account_id AS "availability.account_id"`,
+		`availability.starts_at AS "availability.starts_at"`,
+		`availability.ends_at AS "availability.ends_at"`,
+		`availability.created_at AS "availability.created_at"`,
+	).From("availability AS availability")
+
+	if len(dec) > 0 {
+		sb = sb.Where(dec)
+	}
+
+	if f.Limit > 0 {
+		sb = sb.OrderBy("availability.uuid").Limit(f.Limit)
+	} else {
+		sb = sb.OrderBy("availability.starts_at ASC")
+	}
+
+	sql, args := sb.MustSql()
+
+	var aa []Availability
+
+	if err := sqlx.SelectContext(ctx, qr, &aa, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return aa, nil
+}
+
+func insertAvailability(ctx context.Context, ec sqlx.ExecerContext, a Availability) error {
+	sb := squirrel.Insert("availability").SetMap(map[string]any{
+		"uuid":            a.UUID,
+		"organization_id": a.OrganizationID,
+		"account_id":      a.AccountID,
+		"starts_at":       a.StartsAt,
+		"ends_at":         a.EndsAt,
+		"created_at":      a.CreatedAt,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func deleteAvailability(ctx context.Context, ec sqlx.ExecerContext, auuid uuid.UUID) error {
+	sb := squirrel.Delete("availability").Where(squirrel.Eq{
+		"uuid": auuid,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func insertMatchReschedule(ctx context.Context, ec sqlx.ExecerContext, mr MatchReschedule) error {
+	sb := squirrel.Insert("match_reschedule").SetMap(map[string]any{
+		"uuid":               mr.UUID,
+		"match_uuid":         mr.MatchUUID,
+		"previous_starts_at": mr.PreviousStartsAt,
+		"starts_at":          mr.StartsAt,
+		"reason":             mr.Reason,
+		"rescheduled_by":     mr.RescheduledBy,
+		"created_at":         mr.CreatedAt,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func selectMatchReschedules(ctx context.Context, qr sqlx.QueryerContext, muuid uuid.UUID) ([]MatchReschedule, error) {
+	sb := squirrel.Select(
+		`match_reschedule.uuid AS "match_reschedule.uuid"`,
+		`match_reschedule.match_uuid AS "match_reschedule.match_uuid"`,
+		`match_reschedule.previous_starts_at AS "match_reschedule.previous_starts_at"`,
+		`match_reschedule.starts_at AS "match_reschedule.starts_at"`,
+		`match_reschedule.reason AS "match_reschedule.reason"`,
+		`match_reschedule.rescheduled_by AS "match_reschedule.rescheduled_by"`,
+		`match_reschedule.created_at AS "match_reschedule.created_at"`,
+	).From("match_reschedule AS match_reschedule").Where(squirrel.Eq{
+		"match_reschedule.match_uuid": muuid,
+	}).OrderBy("match_reschedule.created_at ASC")
+
+	sql, args := sb.MustSql()
+
+	var mrr []MatchReschedule
+
+	if err := sqlx.SelectContext(ctx, qr, &mrr, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return mrr, nil
+}
+
+func renameMatchScoutLayout(ctx context.Context, ec sqlx.ExecerContext, oid, from, to string) error {
+	sb := squirrel.Update("match_scout").Set("layout", to).Where(squirrel.And{
+		squirrel.Eq{"layout": from},
+		squirrel.Eq{"finished_at": nil},
+		squirrel.Expr("match_uuid IN (SELECT uuid FROM match WHERE organization_id = ?)", oid),
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
 func matchScoutCols() []string {
 	return []string{
 		`match_scout.match_uuid AS "match_scout.match_uuid"`,
 		`match_scout.account_id AS "match_scout.account_id"`,
 		`match_scout.mode AS "match_scout.mode"`,
 		`match_scout.submode AS "match_scout.submode"`,
+		`match_scout.layout AS "match_scout.layout"`,
+		`match_scout.layout_actions AS "match_scout.layout_actions"`,
+		`match_scout.config_version AS "match_scout.config_version"`,
+		`match_scout.status AS "match_scout.status"`,
+		`match_scout.assigned_by AS "match_scout.assigned_by"`,
 		`match_scout.finished_at AS "match_scout.finished_at"`,
 	}
 }
@@ -354,6 +820,12 @@ func SelectMatchScouts(ctx context.Context, qr sqlx.QueryerContext, f MatchScout
 		})
 	}
 
+	if len(f.MatchUUIDs) > 0 {
+		dec = append(dec, squirrel.Eq{
+			"match_scout.match_uuid": f.MatchUUIDs,
+		})
+	}
+
 	if f.MatchOrganizationID != nil {
 		sb = sb.InnerJoin("match ON match.uuid=match_scout.match_uuid")
 
@@ -362,6 +834,34 @@ func SelectMatchScouts(ctx context.Context, qr sqlx.QueryerContext, f MatchScout
 		})
 	}
 
+	if f.AccountID != nil {
+		dec = append(dec, squirrel.Eq{
+			"match_scout.account_id": *f.AccountID,
+		})
+	}
+
+	if f.Layout != nil {
+		dec = append(dec, squirrel.Eq{
+			"match_scout.layout": *f.Layout,
+		})
+	}
+
+	if len(f.Statuses) > 0 {
+		dec = append(dec, squirrel.Eq{
+			"match_scout.status": f.Statuses,
+		})
+	} else {
+		dec = append(dec, squirrel.Eq{
+			"match_scout.status": MatchScoutStatusAccepted,
+		})
+	}
+
+	if f.Unfinished {
+		dec = append(dec, squirrel.Eq{
+			"match_scout.finished_at": nil,
+		})
+	}
+
 	if len(dec) > 0 {
 		sb = sb.Where(dec)
 	}
@@ -379,10 +879,12 @@ func SelectMatchScouts(ctx context.Context, qr sqlx.QueryerContext, f MatchScout
 
 func insertOrganization(ctx context.Context, ec sqlx.ExecerContext, o Organization) error {
 	sb := squirrel.Insert("organization").SetMap(map[string]any{
-		"id":              o.ID,
-		"scouting_config": o.ScoutingConfig,
-		"created_at":      o.CreatedAt,
-		"modified_at":     o.ModifiedAt,
+		"id":                      o.ID,
+		"scouting_config":         o.ScoutingConfig,
+		"scouting_config_version": o.ScoutingConfigVersion,
+		"max_score_discrepancy":   o.MaxScoreDiscrepancy,
+		"created_at":              o.CreatedAt,
+		"modified_at":             o.ModifiedAt,
 	})
 
 	sql, args := sb.MustSql()
@@ -391,22 +893,42 @@ func insertOrganization(ctx context.Context, ec sqlx.ExecerContext, o Organizati
 	return handleDbError(err)
 }
 
+func updateOrganization(ctx context.Context, ec sqlx.ExecerContext, o Organization) error {
+	sb := squirrel.Update("organization").SetMap(map[string]any{
+		"scouting_config":         o.ScoutingConfig,
+		"scouting_config_version": o.ScoutingConfigVersion,
+		"max_score_discrepancy":   o.MaxScoreDiscrepancy,
+		"modified_at":             o.ModifiedAt,
+	}).Where(squirrel.Eq{"id": o.ID})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
 func organizationCols() []string {
 	return []string{
-		`organization.id AS "organization.uuid"`,
+		`organization.id AS "organization.id"`,
 		`organization.scouting_config AS "organization.scouting_config"`,
+		`organization.scouting_config_version AS "organization.scouting_config_version"`,
+		`organization.max_score_discrepancy AS "organization.max_score_discrepancy"`,
 		`organization.created_at AS "organization.created_at"`,
 		`organization.modified_at AS "organization.modified_at"`,
 	}
 }
 
-func selectOrganizations(ctx context.Context, qr sqlx.QueryerContext, f OrganizationFilter) ([]Organization, error) {
+func selectOrganizations(ctx context.Context, qr sqlx.QueryerContext, f OrganizationFilter, lock bool) ([]Organization, error) {
 	sb := squirrel.Select(organizationCols()...).From("organization AS organization")
 
 	if len(f.IDs) > 0 {
 		sb = sb.Where(squirrel.Eq{"id": f.IDs})
 	}
 
+	if lock {
+		sb = sb.Suffix("FOR UPDATE")
+	}
+
 	sql, args := sb.MustSql()
 
 	var oo []Organization
@@ -418,10 +940,64 @@ func selectOrganizations(ctx context.Context, qr sqlx.QueryerContext, f Organiza
 	return oo, nil
 }
 
+func insertScoutingConfigVersion(ctx context.Context, ec sqlx.ExecerContext, v ScoutingConfigVersion) error {
+	sb := squirrel.Insert("scouting_config_version").SetMap(map[string]any{
+		"organization_id": v.OrganizationID,
+		"version":         v.Version,
+		"scouting_config": v.ScoutingConfig,
+		"created_at":      v.CreatedAt,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func scoutingConfigVersionCols() []string {
+	return []string{
+		`scouting_config_version.organization_id AS "scouting_config_version.organization_id"`,
+		`scouting_config_version.version AS "scouting_config_version.version"`,
+		`scouting_config_version.scouting_config AS "scouting_config_version.scouting_config"`,
+		`scouting_config_version.created_at AS "scouting_config_version.created_at"`,
+	}
+}
+
+func selectScoutingConfigVersions(ctx context.Context, qr sqlx.QueryerContext, f ScoutingConfigVersionFilter) ([]ScoutingConfigVersion, error) {
+	sb := squirrel.Select(scoutingConfigVersionCols()...).
+		From("scouting_config_version AS scouting_config_version").
+		Where(squirrel.Eq{"scouting_config_version.organization_id": f.OrganizationID}).
+		OrderBy("scouting_config_version.version DESC")
+
+	if len(f.Versions) > 0 {
+		sb = sb.Where(squirrel.Eq{"scouting_config_version.version": f.Versions})
+	}
+
+	if f.Before > 0 {
+		sb = sb.Where(squirrel.Lt{"scouting_config_version.version": f.Before})
+	}
+
+	if f.Limit > 0 {
+		sb = sb.Limit(f.Limit)
+	}
+
+	sql, args := sb.MustSql()
+
+	var vv []ScoutingConfigVersion
+
+	if err := sqlx.SelectContext(ctx, qr, &vv, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return vv, nil
+}
+
 func teamCols() []string {
 	return []string{
 		`team.uuid AS "team.uuid"`,
 		`team.name AS "team.name"`,
+		`team.organization_id AS "team.organization_id"`,
+		`team.archived_at AS "team.archived_at"`,
 		`team.created_at AS "team.created_at"`,
 		`team.modified_at AS "team.modified_at"`,
 	}
@@ -431,15 +1007,43 @@ func SelectTeams(ctx context.Context, qr sqlx.QueryerContext, f TeamFilter) ([]T
 	sb := squirrel.Select(teamCols()...).From("team AS team")
 
 	if !f.LeagueUUID.IsNil() {
-		sb = sb.InnerJoin(
-			"league_team ON league_team.team_uuid=team.uuid").
-			Where(squirrel.Eq{"league_uuid": f.LeagueUUID})
+		sb = sb.Where(squirrel.Expr(
+			"team.uuid IN (SELECT season_team.team_uuid FROM season_team "+
+				"INNER JOIN season ON season.uuid=season_team.season_uuid WHERE season.league_uuid = ?)",
+			f.LeagueUUID,
+		))
+	}
+
+	if !f.SeasonUUID.IsNil() {
+		sb = sb.Where(squirrel.Expr(
+			"team.uuid IN (SELECT team_uuid FROM season_team WHERE season_uuid = ?)",
+			f.SeasonUUID,
+		))
 	}
 
 	if len(f.UUIDs) > 0 {
 		sb = sb.Where(squirrel.Eq{"uuid": f.UUIDs})
 	}
 
+	if f.OrganizationID != "" {
+		sb = sb.Where(squirrel.Or{
+			squirrel.Eq{"team.organization_id": f.OrganizationID},
+			squirrel.Eq{"team.organization_id": nil},
+		})
+	}
+
+	if !f.IncludeArchived {
+		sb = sb.Where(squirrel.Eq{"team.archived_at": nil})
+	}
+
+	if !f.After.IsNil() {
+		sb = sb.Where(squirrel.Gt{"team.uuid": f.After})
+	}
+
+	if f.Limit > 0 {
+		sb = sb.OrderBy("team.uuid").Limit(f.Limit)
+	}
+
 	sql, args := sb.MustSql()
 
 	var tt []Team
@@ -453,10 +1057,187 @@ func SelectTeams(ctx context.Context, qr sqlx.QueryerContext, f TeamFilter) ([]T
 
 func insertTeam(ctx context.Context, ec sqlx.ExecerContext, t Team) error {
 	sb := squirrel.Insert("team").SetMap(map[string]any{
-		"uuid":        t.UUID,
+		"uuid":            t.UUID,
+		"name":            t.Name,
+		"organization_id": t.OrganizationID,
+		"archived_at":     t.ArchivedAt,
+		"created_at":      t.CreatedAt,
+		"modified_at":     t.ModifiedAt,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func updateTeam(ctx context.Context, ec sqlx.ExecerContext, t Team) error {
+	sb := squirrel.Update("team").SetMap(map[string]any{
 		"name":        t.Name,
-		"created_at":  t.CreatedAt,
+		"archived_at": t.ArchivedAt,
 		"modified_at": t.ModifiedAt,
+	}).Where(squirrel.Eq{"uuid": t.UUID})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func insertPossession(ctx context.Context, ec sqlx.ExecerContext, p Possession) error {
+	sb := squirrel.Insert("possession").SetMap(map[string]any{
+		"uuid":             p.UUID,
+		"match_uuid":       p.MatchUUID,
+		"account_id":       p.AccountID,
+		"action_id":        p.ActionID,
+		"action_option_id": p.ActionOptionID,
+		"outcome_id":       p.OutcomeID,
+		"sequence":         p.Sequence,
+		"mode":             p.Mode,
+		"play":             p.Play,
+		"rule":             p.Rule,
+		"game_time":        p.GameTime,
+		"created_at":       p.CreatedAt,
+	}).Suffix("ON CONFLICT (match_uuid, account_id, sequence) DO NOTHING")
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func possessionCols() []string {
+	return []string{
+		`possession.uuid AS "possession.uuid"`,
+		`possession.match_uuid AS "possession.match_uuid"`,
+		`possession.account_id AS "possession.account_id"`,
+		`possession.action_id AS "possession.action_id"`,
+		`possession.action_option_id AS "possession.action_option_id"`,
+		`possession.outcome_id AS "possession.outcome_id"`,
+		`possession.sequence AS "possession.sequence"`,
+		`possession.mode AS "possession.mode"`,
+		`possession.play AS "possession.play"`,
+		`possession.rule AS "possession.rule"`,
+		`possession.game_time AS "possession.game_time"`,
+		`possession.created_at AS "possession.created_at"`,
+	}
+}
+
+func SelectPossessions(ctx context.Context, qr sqlx.QueryerContext, f PossessionFilter) ([]Possession, error) {
+	sb := squirrel.Select(possessionCols()...).From("possession AS possession")
+
+	var dec squirrel.And
+
+	if f.MatchUUID != nil {
+		dec = append(dec, squirrel.Eq{
+			"possession.match_uuid": *f.MatchUUID,
+		})
+	}
+
+	if len(f.MatchUUIDs) > 0 {
+		dec = append(dec, squirrel.Eq{
+			"possession.match_uuid": f.MatchUUIDs,
+		})
+	}
+
+	if f.AccountID != nil {
+		dec = append(dec, squirrel.Eq{
+			"possession.account_id": *f.AccountID,
+		})
+	}
+
+	if f.MatchOrganizationID != nil {
+		sb = sb.InnerJoin("match ON match.uuid=possession.match_uuid")
+
+		dec = append(dec, squirrel.Eq{
+			"match.organization_id": *f.MatchOrganizationID,
+		})
+	}
+
+	if len(dec) > 0 {
+		sb = sb.Where(dec)
+	}
+
+	sb = sb.OrderBy("possession.account_id", "possession.sequence")
+
+	sql, args := sb.MustSql()
+
+	var pp []Possession
+
+	if err := sqlx.SelectContext(ctx, qr, &pp, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return pp, nil
+}
+
+func insertMatchReconciliation(ctx context.Context, ec sqlx.ExecerContext, mr MatchReconciliation) error {
+	sb := squirrel.Insert("match_reconciliation").SetMap(map[string]any{
+		"match_uuid":             mr.MatchUUID,
+		"scouted_points_for":     mr.ScoutedPointsFor,
+		"scouted_points_against": mr.ScoutedPointsAgainst,
+		"points_for":             mr.PointsFor,
+		"points_against":         mr.PointsAgainst,
+		"discrepancy":            mr.Discrepancy,
+		"overridden":             mr.Overridden,
+		"created_at":             mr.CreatedAt,
+	})
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func matchReconciliationCols() []string {
+	return []string{
+		`match_reconciliation.match_uuid AS "match_reconciliation.match_uuid"`,
+		`match_reconciliation.scouted_points_for AS "match_reconciliation.scouted_points_for"`,
+		`match_reconciliation.scouted_points_against AS "match_reconciliation.scouted_points_against"`,
+		`match_reconciliation.points_for AS "match_reconciliation.points_for"`,
+		`match_reconciliation.points_against AS "match_reconciliation.points_against"`,
+		`match_reconciliation.discrepancy AS "match_reconciliation.discrepancy"`,
+		`match_reconciliation.overridden AS "match_reconciliation.overridden"`,
+		`match_reconciliation.created_at AS "match_reconciliation.created_at"`,
+	}
+}
+
+func SelectMatchReconciliations(ctx context.Context, qr sqlx.QueryerContext, f MatchReconciliationFilter) ([]MatchReconciliation, error) {
+	sb := squirrel.Select(matchReconciliationCols()...).From("match_reconciliation AS match_reconciliation")
+
+	if len(f.MatchUUIDs) > 0 {
+		sb = sb.Where(squirrel.Eq{"match_reconciliation.match_uuid": f.MatchUUIDs})
+	}
+
+	sql, args := sb.MustSql()
+
+	var mrr []MatchReconciliation
+
+	if err := sqlx.SelectContext(ctx, qr, &mrr, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return mrr, nil
+}
+
+func upsertCalendarToken(ctx context.Context, ec sqlx.ExecerContext, ct CalendarToken) error {
+	sb := squirrel.Insert("calendar_token").SetMap(map[string]any{
+		"token_hash":      ct.TokenHash,
+		"organization_id": ct.OrganizationID,
+		"account_id":      ct.AccountID,
+		"created_at":      ct.CreatedAt,
+	}).Suffix("ON CONFLICT (organization_id, account_id) DO UPDATE SET token_hash=EXCLUDED.token_hash, created_at=EXCLUDED.created_at")
+
+	sql, args := sb.MustSql()
+
+	_, err := ec.ExecContext(ctx, sql, args...)
+	return handleDbError(err)
+}
+
+func deleteCalendarToken(ctx context.Context, ec sqlx.ExecerContext, oid, aid string) error {
+	sb := squirrel.Delete("calendar_token").Where(squirrel.Eq{
+		"organization_id": oid,
+		"account_id":      aid,
 	})
 
 	sql, args := sb.MustSql()
@@ -465,6 +1246,27 @@ func insertTeam(ctx context.Context, ec sqlx.ExecerContext, t Team) error {
 	return handleDbError(err)
 }
 
+func selectCalendarTokens(ctx context.Context, qr sqlx.QueryerContext, tokenHash string) ([]CalendarToken, error) {
+	sb := squirrel.Select(
+		`calendar_token.token_hash AS "calendar_token.token_hash"`,
+		`calendar_token.organization_id AS "calendar_token.organization_id"`,
+		`calendar_token.account_id AS "calendar_token.account_id"`,
+		`calendar_token.created_at AS "calendar_token.created_at"`,
+	).From("calendar_token AS calendar_token").Where(squirrel.Eq{
+		"calendar_token.token_hash": tokenHash,
+	})
+
+	sql, args := sb.MustSql()
+
+	var ct []CalendarToken
+
+	if err := sqlx.SelectContext(ctx, qr, &ct, sql, args...); err != nil {
+		return nil, handleDbError(err)
+	}
+
+	return ct, nil
+}
+
 func handleDbError(err error) error {
 	var pge *pgconn.PgError
 
@@ -0,0 +1,122 @@
+package scouting
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+func (sc *ScoutingConfig) layoutIndex(name string) int {
+	return slices.IndexFunc(sc.Layouts, func(l Layout) bool {
+		return l.Name == name
+	})
+}
+
+// CreateLayout appends a new layout to the organization scouting config.
+func CreateLayout(ctx context.Context, sdb *sqlx.DB, oid string, l Layout) (Layout, error) {
+	_, err := changeScoutingConfig(ctx, sdb, oid, func(_ *sqlx.Tx, cfg *ScoutingConfig) error {
+		cfg.Layouts = append(cfg.Layouts, l)
+
+		return nil
+	})
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return l, nil
+}
+
+// UpdateLayout renames the layout and replaces its ordered list of actions.
+// Unfinished match scouts using the layout follow the rename but keep the
+// actions they claimed the match with.
+func UpdateLayout(ctx context.Context, sdb *sqlx.DB, oid, name string, l Layout) (Layout, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("layout", name),
+	)
+
+	_, err := changeScoutingConfig(ctx, sdb, oid, func(tx *sqlx.Tx, cfg *ScoutingConfig) error {
+		i := cfg.layoutIndex(name)
+		if i < 0 {
+			return sbd.NewNotFoundError("layout")
+		}
+
+		cfg.Layouts[i] = l
+
+		if l.Name == name {
+			return nil
+		}
+
+		if err := renameMatchScoutLayout(ctx, tx, oid, name, l.Name); err != nil {
+			logger.Error("renaming match scout layout", slog.Any("error", err))
+
+			return errInternal
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return l, nil
+}
+
+// DeleteLayout removes the layout from the organization scouting config. It
+// fails while an unfinished match scout is using the layout.
+func DeleteLayout(ctx context.Context, sdb *sqlx.DB, oid, name string) error {
+	_, err := changeScoutingConfig(ctx, sdb, oid, func(_ *sqlx.Tx, cfg *ScoutingConfig) error {
+		i := cfg.layoutIndex(name)
+		if i < 0 {
+			return sbd.NewNotFoundError("layout")
+		}
+
+		cfg.Layouts = slices.Delete(cfg.Layouts, i, i+1)
+
+		return nil
+	})
+
+	return err
+}
+
+func layoutNames(cfg ScoutingConfig) []string {
+	names := make([]string, len(cfg.Layouts))
+
+	for i, l := range cfg.Layouts {
+		names[i] = l.Name
+	}
+
+	return names
+}
+
+// checkRemovedLayouts rejects removing layouts that unfinished match scouts
+// are still using, whichever way the config is changed.
+func checkRemovedLayouts(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, prev []string, cfg ScoutingConfig) error {
+	for _, name := range prev {
+		if cfg.layoutIndex(name) >= 0 {
+			continue
+		}
+
+		mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+			MatchOrganizationID: &oid,
+			Layout:              &name,
+			Statuses:            claimingMatchScoutStatuses,
+			Unfinished:          true,
+		})
+		if err != nil {
+			logger.Error("selecting match scouts", slog.Any("error", err))
+
+			return errInternal
+		}
+
+		if len(mss) > 0 {
+			return sbd.NewValidationError(fmt.Sprintf("layout %q is used by active match scouts", name))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package scouting
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+func (s *Suite) Test_Layouts() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	l, err := CreateLayout(context.Background(), s.sdb, "o1", Layout{
+		Name:    "Quick",
+		Actions: []string{"w5", "w4"},
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal("Quick", l.Name)
+
+	_, err = CreateLayout(context.Background(), s.sdb, "o1", Layout{Name: "Quick"})
+	s.Assert().Equal(sbd.NewValidationError(`layouts[3].name: duplicate layout "Quick", first defined at layouts[2]`), err)
+
+	_, err = CreateLayout(context.Background(), s.sdb, "o1", Layout{
+		Name:    "Other",
+		Actions: []string{"tw5"},
+	})
+	s.Assert().Equal(sbd.NewValidationError(`layouts[3].actions[0]: unknown action "tw5"`), err)
+
+	_, err = CreateLayout(context.Background(), s.sdb, "o1", Layout{Name: "Other"})
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	m := s.createMatch("o1")
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+		Layout:  "Quick",
+	})
+	s.Require().NoError(err)
+
+	_, err = UpdateLayout(context.Background(), s.sdb, "o1", "Unknown", Layout{Name: "Unknown"})
+	s.Assert().Equal(sbd.NewNotFoundError("layout"), err)
+
+	l, err = UpdateLayout(context.Background(), s.sdb, "o1", "Quick", Layout{
+		Name:    "Simple",
+		Actions: []string{"w4", "w5"},
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"w4", "w5"}, l.Actions)
+
+	cnt := s.selectCount("match_scout", squirrel.Eq{
+		"account_id": a.ID,
+		"layout":     "Simple",
+	})
+	s.Assert().Equal(1, cnt)
+
+	// The match scout keeps the actions it claimed the match with.
+	mss, err := SelectMatchScouts(context.Background(), s.sdb, MatchScoutFilter{
+		MatchUUID: &m.UUID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(mss, 1)
+	s.Assert().Equal(LayoutActions{"w5", "w4"}, mss[0].LayoutActions)
+
+	err = DeleteLayout(context.Background(), s.sdb, "o1", "Simple")
+	s.Assert().Equal(sbd.NewValidationError(`layout "Simple" is used by active match scouts`), err)
+
+	// Replacing the whole config cannot drop the layout either.
+	_, err = UpdateScoutingConfig(context.Background(), s.sdb, "o1", ScoutingConfig{
+		Actions:  []Action{{ID: "w5"}, {ID: "w4"}},
+		Outcomes: []Outcome{{ID: "2p", Points: 2, EndedInShot: true}},
+	})
+	s.Assert().Equal(sbd.NewValidationError(`layout "Simple" is used by active match scouts`), err)
+
+	err = DeleteLayout(context.Background(), s.sdb, "o1", "Other")
+	s.Require().NoError(err)
+
+	oo, err := SelectOrganizations(context.Background(), s.sdb, OrganizationFilter{
+		IDs: []string{"o1"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(oo, 1)
+	s.Assert().Equal(append(DefaultScoutingConfig.Layouts[:2:2], Layout{
+		Name:    "Simple",
+		Actions: []string{"w4", "w5"},
+	}), oo[0].ScoutingConfig.Layouts)
+}
+
+func (s *Suite) Test_Layouts_LegacyOrganization() {
+	s.createLegacyOrganization("o1")
+	s.migrateLegacy()
+
+	_, err := CreateLayout(context.Background(), s.sdb, "o1", Layout{
+		Name:    "Quick",
+		Actions: []string{"w5", "w4"},
+	})
+	s.Require().NoError(err)
+
+	_, err = UpdateLayout(context.Background(), s.sdb, "o1", "Detailed", Layout{
+		Name:    "Detailed",
+		Actions: []string{"1x1", "w5"},
+	})
+	s.Require().NoError(err)
+
+	err = DeleteLayout(context.Background(), s.sdb, "o1", "Quick")
+	s.Require().NoError(err)
+
+	data, err := MarshalScoutingConfig(DefaultScoutingConfig, ConfigFormatYAML)
+	s.Require().NoError(err)
+
+	ci, err := ImportScoutingConfig(context.Background(), s.sdb, "o1", data, ConfigFormatYAML, false)
+	s.Require().NoError(err)
+	s.Assert().True(ci.Applied)
+}
@@ -2,21 +2,26 @@ package scouting
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
 )
 
 type NewLeague struct {
-	Name      string      `json:"name"`
-	TeamUUIDs []uuid.UUID `json:"team_uuids"`
+	Name   string    `json:"name"`
+	Season NewSeason `json:"season"`
 }
 
+// LeagueFilter selects leagues. When Limit is set, leagues are ordered by
+// their time ordered uuid and start after the After uuid.
 type LeagueFilter struct {
 	LeagueUUID     uuid.UUID
 	OrganizationID string
+	After          uuid.UUID
+	Limit          uint64
 }
 
 func (nl *NewLeague) ToLeague() League {
@@ -63,40 +68,41 @@ func UpdateOrganizationLeagues(ctx context.Context, sdb *sqlx.DB, oid string, lu
 	return nil
 }
 
-func CreateLeague(ctx context.Context, nl NewLeague, sdb *sqlx.DB) (League, error) {
-	tx, err := sdb.BeginTxx(ctx, nil)
-	if err != nil {
-		return League{}, err
-	}
+// CreateLeague creates a league with its first season.
+func CreateLeague(ctx context.Context, sdb *sqlx.DB, oid string, nl NewLeague) (League, Season, error) {
+	logger := slog.With(slog.String("organization_id", oid))
 
-	defer tx.Rollback()
+	if nl.Name == "" {
+		return League{}, Season{}, sbd.NewValidationError("name is required")
+	}
 
-	tt, err := SelectTeams(ctx, tx, TeamFilter{
-		UUIDs: nl.TeamUUIDs,
-	})
+	tx, err := sdb.BeginTxx(ctx, nil)
 	if err != nil {
-		return League{}, err
-	}
+		logger.Error("beginning tx", slog.Any("error", err))
 
-	if len(tt) != len(nl.TeamUUIDs) {
-		return League{}, fmt.Errorf("expected %d teams, found %d", len(nl.TeamUUIDs), len(tt))
+		return League{}, Season{}, errInternal
 	}
 
+	defer tx.Rollback()
+
 	l := nl.ToLeague()
 
-	if err := insertLeague(ctx, tx, l); err != nil {
-		return League{}, fmt.Errorf("inserting league: %w", err)
+	if err = insertLeague(ctx, tx, l); err != nil {
+		logger.Error("inserting league", slog.Any("error", err))
+
+		return League{}, Season{}, errInternal
 	}
 
-	for _, t := range tt {
-		if err := insertLeagueTeam(ctx, tx, l.UUID, t.UUID); err != nil {
-			return League{}, fmt.Errorf("inserting league team: %w", err)
-		}
+	s, err := addSeason(ctx, tx, logger, oid, l.UUID, nl.Season)
+	if err != nil {
+		return League{}, Season{}, err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return League{}, fmt.Errorf("commiting: %w", err)
+		logger.Error("commiting", slog.Any("error", err))
+
+		return League{}, Season{}, errInternal
 	}
 
-	return l, nil
+	return l, s, nil
 }
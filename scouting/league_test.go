@@ -8,23 +8,22 @@ import (
 )
 
 func (s *Suite) Test_CreateLeague() {
-	t1, err := CreateTeam(context.Background(), NewTeam{
-		Name: "t1",
-	}, s.sdb)
+	t1, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "t1",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	t2, err := CreateTeam(context.Background(), NewTeam{
-		Name: "t2",
-	}, s.sdb)
+	t2, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "t2",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	l, err := CreateLeague(context.Background(), NewLeague{
-		Name: "league",
-		TeamUUIDs: []uuid.UUID{
-			t1.UUID,
-			t2.UUID,
-		},
-	}, s.sdb)
+	l, season, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+		Name:   "league",
+		Season: testSeason(t1.UUID, t2.UUID),
+	})
 	s.Require().NoError(err)
 
 	s.Assert().NotEmpty(l.UUID)
@@ -36,38 +35,38 @@ func (s *Suite) Test_CreateLeague() {
 	})
 	s.Assert().Equal(1, cnt)
 
-	cnt = s.selectCount("league_team", squirrel.Eq{
-		"league_uuid": l.UUID,
+	s.Assert().Equal(l.UUID, season.LeagueUUID)
+
+	cnt = s.selectCount("season_team", squirrel.Eq{
+		"season_uuid": season.UUID,
 	})
 
 	s.Assert().Equal(2, cnt)
 }
 
 func (s *Suite) Test_UpdateOrganizationLeagues() {
-	t1, err := CreateTeam(context.Background(), NewTeam{
-		Name: "t1",
-	}, s.sdb)
+	t1, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "t1",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	t2, err := CreateTeam(context.Background(), NewTeam{
-		Name: "t2",
-	}, s.sdb)
+	t2, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "t2",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	l1, err := CreateLeague(context.Background(), NewLeague{
-		Name: "league",
-		TeamUUIDs: []uuid.UUID{
-			t1.UUID,
-		},
-	}, s.sdb)
+	l1, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+		Name:   "league",
+		Season: testSeason(t1.UUID),
+	})
 	s.Require().NoError(err)
 
-	l2, err := CreateLeague(context.Background(), NewLeague{
-		Name: "league",
-		TeamUUIDs: []uuid.UUID{
-			t2.UUID,
-		},
-	}, s.sdb)
+	l2, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+		Name:   "league",
+		Season: testSeason(t2.UUID),
+	})
 	s.Require().NoError(err)
 
 	_, err = CreateOrganization(context.Background(), s.sdb, "o1")
@@ -3,6 +3,7 @@ package scouting
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -13,40 +14,55 @@ import (
 )
 
 type Match struct {
-	UUID           uuid.UUID             `db:"match.uuid"`
-	LeagueUUID     uuid.UUID             `db:"match.league_uuid"`
-	AwayTeamUUID   uuid.UUID             `db:"match.away_team_uuid"`
-	HomeTeamUUID   uuid.UUID             `db:"match.home_team_uuid"`
-	CreatedBy      string                `db:"match.created_by"`
-	HomeScore      null.Value[uint]      `db:"match.home_score"`
-	AwayScore      null.Value[uint]      `db:"match.away_score"`
-	OrganizationID string                `db:"match.organization_id"`
-	StartsAt       time.Time             `db:"match.starts_at"`
-	FinishedAt     null.Value[time.Time] `db:"match.finished_at"`
-	CreatedAt      time.Time             `db:"match.created_at"`
-	ModifiedAt     time.Time             `db:"match.modified_at"`
+	UUID            uuid.UUID             `db:"match.uuid"`
+	LeagueUUID      uuid.UUID             `db:"match.league_uuid"`
+	SeasonUUID      uuid.UUID             `db:"match.season_uuid"`
+	AwayTeamUUID    uuid.UUID             `db:"match.away_team_uuid"`
+	HomeTeamUUID    uuid.UUID             `db:"match.home_team_uuid"`
+	ScoutedTeamUUID uuid.UUID             `db:"match.scouted_team_uuid"`
+	CreatedBy       string                `db:"match.created_by"`
+	HomeScore       null.Value[uint]      `db:"match.home_score"`
+	AwayScore       null.Value[uint]      `db:"match.away_score"`
+	OrganizationID  string                `db:"match.organization_id"`
+	StartsAt        time.Time             `db:"match.starts_at"`
+	Status          MatchStatus           `db:"match.status"`
+	FinishedAt      null.Value[time.Time] `db:"match.finished_at"`
+	CreatedAt       time.Time             `db:"match.created_at"`
+	ModifiedAt      time.Time             `db:"match.modified_at"`
 }
 
+// NewMatch describes a match of a league season. When SeasonUUID is not set,
+// the season running at StartsAt is used.
 type NewMatch struct {
-	LeagueUUID   uuid.UUID `json:"league_uuid"`
-	AwayTeamUUID uuid.UUID `json:"away_team_uuid"`
-	HomeTeamUUID uuid.UUID `json:"home_team_uuid"`
-	StartsAt     time.Time `json:"starts_at"`
+	LeagueUUID      uuid.UUID `json:"league_uuid"`
+	SeasonUUID      uuid.UUID `json:"season_uuid"`
+	AwayTeamUUID    uuid.UUID `json:"away_team_uuid"`
+	HomeTeamUUID    uuid.UUID `json:"home_team_uuid"`
+	ScoutedTeamUUID uuid.UUID `json:"scouted_team_uuid"`
+	StartsAt        time.Time `json:"starts_at"`
 }
 
-func (nm *NewMatch) ToMatch(oid, aid string) Match {
+func (nm *NewMatch) ToMatch(oid, aid string, seasonUUID uuid.UUID) Match {
 	tnow := time.Now()
 
+	scouted := nm.ScoutedTeamUUID
+	if scouted.IsNil() {
+		scouted = nm.HomeTeamUUID
+	}
+
 	return Match{
-		UUID:           uuid.Must(uuid.NewV7()),
-		LeagueUUID:     nm.LeagueUUID,
-		CreatedBy:      aid,
-		AwayTeamUUID:   nm.AwayTeamUUID,
-		HomeTeamUUID:   nm.HomeTeamUUID,
-		OrganizationID: oid,
-		StartsAt:       nm.StartsAt,
-		CreatedAt:      tnow,
-		ModifiedAt:     tnow,
+		UUID:            uuid.Must(uuid.NewV7()),
+		LeagueUUID:      nm.LeagueUUID,
+		SeasonUUID:      seasonUUID,
+		CreatedBy:       aid,
+		AwayTeamUUID:    nm.AwayTeamUUID,
+		HomeTeamUUID:    nm.HomeTeamUUID,
+		ScoutedTeamUUID: scouted,
+		OrganizationID:  oid,
+		StartsAt:        nm.StartsAt,
+		Status:          MatchStatusScheduled,
+		CreatedAt:       tnow,
+		ModifiedAt:      tnow,
 	}
 }
 
@@ -55,9 +71,32 @@ func (m *Match) Validate() error {
 		return errors.New("starts at cannot be before now")
 	}
 
+	if m.ScoutedTeamUUID != m.HomeTeamUUID && m.ScoutedTeamUUID != m.AwayTeamUUID {
+		return errors.New("scouted team must be home or away team")
+	}
+
 	return nil
 }
 
+// PointsFor returns the score of the scouted team, whose offence is recorded
+// as attack possessions.
+func (m *Match) PointsFor() uint {
+	if m.ScoutedTeamUUID == m.AwayTeamUUID {
+		return m.AwayScore.V
+	}
+
+	return m.HomeScore.V
+}
+
+// PointsAgainst returns the opponent score.
+func (m *Match) PointsAgainst() uint {
+	if m.ScoutedTeamUUID == m.AwayTeamUUID {
+		return m.HomeScore.V
+	}
+
+	return m.AwayScore.V
+}
+
 type Mode string
 
 const (
@@ -96,10 +135,26 @@ func CreateMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, nm NewMatch
 
 	defer tx.Rollback()
 
+	m, err := createMatch(ctx, tx, logger, oid, aid, nm)
+	if err != nil {
+		return Match{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Match{}, errInternal
+	}
+
+	return m, nil
+}
+
+// createMatch validates and stores the match within the transaction.
+func createMatch(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid, aid string, nm NewMatch) (Match, error) {
 	leagues, err := SelectLeagues(ctx, tx, LeagueFilter{
 		LeagueUUID:     nm.LeagueUUID,
 		OrganizationID: oid,
-	})
+	}, false)
 	switch {
 	case err == nil && len(leagues) > 0:
 		// OK.
@@ -111,23 +166,42 @@ func CreateMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, nm NewMatch
 		return Match{}, errInternal
 	}
 
-	league := leagues[0]
+	ss, err := SelectSeasons(ctx, tx, SeasonFilter{
+		UUID:       nm.SeasonUUID,
+		LeagueUUID: leagues[0].UUID,
+	})
+	if err != nil {
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return Match{}, errInternal
+	}
+
+	season, ok := seasonAt(ss, nm.StartsAt)
+	switch {
+	case ok:
+		// OK.
+	case !nm.SeasonUUID.IsNil() && len(ss) > 0:
+		return Match{}, sbd.NewValidationError("match must start within the season")
+	default:
+		return Match{}, sbd.NewNotFoundError("season")
+	}
 
 	teams, err := SelectTeams(ctx, tx, TeamFilter{
-		LeagueUUID: league.UUID,
-		UUIDs:      []uuid.UUID{nm.HomeTeamUUID, nm.AwayTeamUUID},
+		SeasonUUID:     season.UUID,
+		UUIDs:          []uuid.UUID{nm.HomeTeamUUID, nm.AwayTeamUUID},
+		OrganizationID: oid,
 	})
 	if err != nil {
-		logger.Error("selecting league teams", slog.Any("error", err))
+		logger.Error("selecting season teams", slog.Any("error", err))
 
 		return Match{}, errInternal
 	}
 
 	if len(teams) != 2 {
-		return Match{}, sbd.NewValidationError("team not found in league")
+		return Match{}, sbd.NewValidationError("team not found in season")
 	}
 
-	m := nm.ToMatch(oid, aid)
+	m := nm.ToMatch(oid, aid, season.UUID)
 
 	if err := m.Validate(); err != nil {
 		return Match{}, sbd.NewValidationError(err.Error())
@@ -139,18 +213,9 @@ func CreateMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, nm NewMatch
 		return Match{}, errors.Join(err, errInternal)
 	}
 
-	if err = tx.Commit(); err != nil {
-		logger.Error("commiting", slog.Any("error", err))
-
-		return Match{}, errInternal
-	}
-
 	return m, nil
 }
 
-type ScoutReport struct {
-}
-
 func SubmitScoutReport(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, sr ScoutReport) (MatchScout, error) {
 	logger := slog.With(
 		slog.String("organization_id", oid),
@@ -164,6 +229,8 @@ func SubmitScoutReport(ctx context.Context, sdb *sqlx.DB, oid, aid string, match
 		return MatchScout{}, errInternal
 	}
 
+	defer tx.Rollback()
+
 	mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
 		MatchUUID:           &matchUUID,
 		MatchOrganizationID: &oid,
@@ -192,6 +259,53 @@ func SubmitScoutReport(ctx context.Context, sdb *sqlx.DB, oid, aid string, match
 		return MatchScout{}, sbd.NewValidationError("match scout already finished")
 	}
 
+	vv, err := selectScoutingConfigVersions(ctx, tx, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+		Versions:       []uint{ms.ConfigVersion},
+	})
+	switch {
+	case err == nil && len(vv) > 0:
+		// OK.
+	case err == nil && len(vv) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("scouting config version")
+	default:
+		logger.Error("selecting scouting config versions", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	if err = sr.Validate(vv[0].ScoutingConfig, *ms); err != nil {
+		return MatchScout{}, sbd.NewValidationError(err.Error())
+	}
+
+	recorded, err := SelectPossessions(ctx, tx, PossessionFilter{
+		MatchUUID: &matchUUID,
+		AccountID: &aid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	pp, err := numberPossessions(sr.Possessions, recorded)
+	if err != nil {
+		return MatchScout{}, sbd.NewValidationError(err.Error())
+	}
+
+	pp, err = unrecordedPossessions(pp, recorded)
+	if err != nil {
+		return MatchScout{}, sbd.NewValidationError(err.Error())
+	}
+
+	for _, np := range pp {
+		if err = insertPossession(ctx, tx, np.ToPossession(*ms)); err != nil {
+			logger.Error("inserting possession", slog.Any("error", err))
+
+			return MatchScout{}, errInternal
+		}
+	}
+
 	tnow := time.Now()
 
 	ms.FinishedAt = null.NewValue(tnow, true)
@@ -202,8 +316,6 @@ func SubmitScoutReport(ctx context.Context, sdb *sqlx.DB, oid, aid string, match
 		return MatchScout{}, errInternal
 	}
 
-	defer tx.Rollback()
-
 	if err = tx.Commit(); err != nil {
 		logger.Error("commiting", slog.Any("error", err))
 
@@ -216,6 +328,9 @@ func SubmitScoutReport(ctx context.Context, sdb *sqlx.DB, oid, aid string, match
 type MatchFinishRequest struct {
 	HomeScore uint `json:"home_score"`
 	AwayScore uint `json:"away_score"`
+	// Override allows finishing the match when the final score differs from
+	// scouted points by more than the organization allows.
+	Override bool `json:"override"`
 }
 
 func FinishMatch(
@@ -224,7 +339,7 @@ func FinishMatch(
 	oid string,
 	matchUUID uuid.UUID,
 	fr MatchFinishRequest,
-) (Match, error) {
+) (Match, MatchReconciliation, error) {
 	logger := slog.With(
 		slog.String("organization_id", oid),
 		slog.String("match_uuid", matchUUID.String()),
@@ -234,7 +349,7 @@ func FinishMatch(
 	if err != nil {
 		logger.Error("beginning tx", slog.Any("error", err))
 
-		return Match{}, errInternal
+		return Match{}, MatchReconciliation{}, errInternal
 	}
 
 	defer tx.Rollback()
@@ -248,11 +363,11 @@ func FinishMatch(
 	case err == nil && len(mm) > 0:
 		// OK.
 	case err == nil && len(mm) == 0:
-		return Match{}, sbd.NewNotFoundError("match")
+		return Match{}, MatchReconciliation{}, sbd.NewNotFoundError("match")
 	default:
 		logger.Error("selecting organization matches", slog.Any("error", err))
 
-		return Match{}, errInternal
+		return Match{}, MatchReconciliation{}, errInternal
 	}
 
 	m := mm[0]
@@ -264,39 +379,110 @@ func FinishMatch(
 	if err != nil {
 		logger.Error("selecting match scouts", slog.Any("error", err))
 
-		return Match{}, errInternal
+		return Match{}, MatchReconciliation{}, errInternal
 	}
 
 	if err := validateMatchFinish(m, mss); err != nil {
-		return Match{}, sbd.NewValidationError(err.Error())
+		return Match{}, MatchReconciliation{}, sbd.NewValidationError(err.Error())
+	}
+
+	oo, err := selectOrganizations(ctx, tx, OrganizationFilter{
+		IDs: []string{oid},
+	}, false)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return Match{}, MatchReconciliation{}, sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return Match{}, MatchReconciliation{}, errInternal
+	}
+
+	pp, err := SelectPossessions(ctx, tx, PossessionFilter{
+		MatchUUID: &m.UUID,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return Match{}, MatchReconciliation{}, errInternal
 	}
 
 	now := time.Now()
 
 	m.HomeScore = null.NewValue(fr.HomeScore, true)
 	m.AwayScore = null.NewValue(fr.AwayScore, true)
+	m.Status = MatchStatusFinished
 	m.FinishedAt = null.NewValue(now, true)
 	m.ModifiedAt = now
 
+	vv, err := selectScoutingConfigVersions(ctx, tx, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting scouting config versions", slog.Any("error", err))
+
+		return Match{}, MatchReconciliation{}, errInternal
+	}
+
+	o := oo[0]
+
+	mr := reconcileMatch(m, newConfigSet(vv, mss), mss, pp)
+	mr.CreatedAt = now
+
+	if o.MaxScoreDiscrepancy > 0 && mr.Discrepancy > o.MaxScoreDiscrepancy {
+		if !fr.Override {
+			return Match{}, MatchReconciliation{}, sbd.NewValidationError(
+				fmt.Sprintf("final score differs from scouted points by %d", mr.Discrepancy),
+			)
+		}
+
+		// Only overrides that bypassed the check are recorded.
+		mr.Overridden = true
+	}
+
 	if err = updateMatch(ctx, tx, m); err != nil {
 		logger.Error("updating match", slog.Any("error", err))
 
-		return Match{}, errInternal
+		return Match{}, MatchReconciliation{}, errInternal
+	}
+
+	if err = insertMatchReconciliation(ctx, tx, mr); err != nil {
+		logger.Error("inserting match reconciliation", slog.Any("error", err))
+
+		return Match{}, MatchReconciliation{}, errInternal
 	}
 
 	if err = tx.Commit(); err != nil {
 		logger.Error("commiting", slog.Any("error", err))
 
-		return Match{}, errInternal
+		return Match{}, MatchReconciliation{}, errInternal
 	}
 
-	return m, nil
+	return m, mr, nil
 }
 
+// MatchFilter selects matches. Active returns scheduled, live and postponed
+// matches, otherwise finished ones unless AnyState is set. CurrentSeasonAt
+// returns matches of the season each league considers current at the given
+// time, see currentSeason, and ScoutAccountID matches claimed by the
+// account. When Limit is set, matches are ordered by their time ordered uuid
+// and start after the After uuid.
 type MatchFilter struct {
-	Active         bool
-	UUID           uuid.UUID
-	OrganizationID string
+	Active          bool
+	AnyState        bool
+	UUID            uuid.UUID
+	LeagueUUID      uuid.UUID
+	SeasonUUID      uuid.UUID
+	CurrentSeasonAt time.Time
+	TeamUUID        uuid.UUID
+	ScoutAccountID  string
+	StartsFrom      time.Time
+	StartsTo        time.Time
+	OrganizationID  string
+	After           uuid.UUID
+	Limit           uint64
 }
 
 func validateMatchFinish(m Match, mss []MatchScout) error {
@@ -304,6 +490,10 @@ func validateMatchFinish(m Match, mss []MatchScout) error {
 		return errors.New("match already finished")
 	}
 
+	if !m.Status.canTransitionTo(MatchStatusFinished) {
+		return fmt.Errorf("%s match cannot be finished", m.Status)
+	}
+
 	for _, ms := range mss {
 		if !ms.FinishedAt.Valid {
 			return errors.New("not all scouts have finished")
@@ -313,7 +503,7 @@ func validateMatchFinish(m Match, mss []MatchScout) error {
 	return nil
 }
 
-func matchScoutable(_ Match, aid string, mss []MatchScout, sr NewMatchScout) error {
+func matchScoutable(_ Match, cfg ScoutingConfig, aid string, mss []MatchScout, sr NewMatchScout) error {
 	for _, ms := range mss {
 		if ms.AccountID == aid {
 			return errors.New("account already scouting this match")
@@ -324,6 +514,12 @@ func matchScoutable(_ Match, aid string, mss []MatchScout, sr NewMatchScout) err
 		return errors.New("mode and submode combination not valid")
 	}
 
+	if sr.Layout != "" {
+		if _, ok := cfg.layout(sr.Layout); !ok {
+			return errors.New("layout not found")
+		}
+	}
+
 	for _, ms := range mss {
 		if modesSubmodesConflicts(modeSubmode{ms.Mode, ms.Submode}, modeSubmode{sr.Mode, sr.Submode}) {
 			return errors.New("mode and submode conflicts with other scouts")
@@ -422,22 +618,52 @@ func modeSubmodeValid(m Mode, sm Submode) bool {
 	return false
 }
 
+// MatchScoutFilter selects match scouts. Only accepted scouts are returned
+// unless Statuses is set.
 type MatchScoutFilter struct {
 	MatchUUID           *uuid.UUID
+	MatchUUIDs          []uuid.UUID
 	MatchOrganizationID *string
+	AccountID           *string
+	Layout              *string
+	Statuses            []MatchScoutStatus
+	Unfinished          bool
 }
 
+type MatchScoutStatus string
+
+const (
+	MatchScoutStatusPending  MatchScoutStatus = "pending"
+	MatchScoutStatusAccepted MatchScoutStatus = "accepted"
+	MatchScoutStatusDeclined MatchScoutStatus = "declined"
+)
+
+// claimingMatchScoutStatuses hold a mode and submode of the match.
+var claimingMatchScoutStatuses = []MatchScoutStatus{
+	MatchScoutStatusPending,
+	MatchScoutStatusAccepted,
+}
+
+// MatchScout is a scouting slot of a match. Slots assigned by a coordinator
+// are pending until the assignee accepts or declines them, self-claimed
+// slots are accepted right away.
 type MatchScout struct {
-	MatchUUID  uuid.UUID             `db:"match_scout.match_uuid"`
-	AccountID  string                `db:"match_scout.account_id"`
-	Mode       Mode                  `db:"match_scout.mode"`
-	Submode    Submode               `db:"match_scout.submode"`
-	FinishedAt null.Value[time.Time] `db:"match_scout.finished_at"`
+	MatchUUID     uuid.UUID             `db:"match_scout.match_uuid"`
+	AccountID     string                `db:"match_scout.account_id"`
+	Mode          Mode                  `db:"match_scout.mode"`
+	Submode       Submode               `db:"match_scout.submode"`
+	Layout        null.Value[string]    `db:"match_scout.layout"`
+	LayoutActions LayoutActions         `db:"match_scout.layout_actions"`
+	ConfigVersion uint                  `db:"match_scout.config_version"`
+	Status        MatchScoutStatus      `db:"match_scout.status"`
+	AssignedBy    null.Value[string]    `db:"match_scout.assigned_by"`
+	FinishedAt    null.Value[time.Time] `db:"match_scout.finished_at"`
 }
 
 type NewMatchScout struct {
 	Mode    Mode    `json:"mode"`
 	Submode Submode `json:"submode"`
+	Layout  string  `json:"layout"`
 }
 
 func ScoutMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, sr NewMatchScout) error {
@@ -476,6 +702,7 @@ func ScoutMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uu
 
 	mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
 		MatchUUID: &m.UUID,
+		Statuses:  claimingMatchScoutStatuses,
 	})
 	if err != nil {
 		logger.Error("selecting match scouts", slog.Any("error", err))
@@ -483,18 +710,44 @@ func ScoutMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uu
 		return errInternal
 	}
 
-	if err := matchScoutable(m, aid, mss, sr); err != nil {
+	oo, err := selectOrganizations(ctx, tx, OrganizationFilter{
+		IDs: []string{oid},
+	}, false)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	if err := matchScoutable(m, oo[0].ScoutingConfig, aid, mss, sr); err != nil {
 		return sbd.NewValidationError(err.Error())
 	}
 
 	ms := MatchScout{
-		MatchUUID: m.UUID,
-		AccountID: aid,
-		Mode:      sr.Mode,
-		Submode:   sr.Submode,
+		MatchUUID:     m.UUID,
+		AccountID:     aid,
+		Mode:          sr.Mode,
+		Submode:       sr.Submode,
+		Layout:        null.NewValue(sr.Layout, sr.Layout != ""),
+		LayoutActions: oo[0].ScoutingConfig.layoutActions(sr.Layout),
+		ConfigVersion: oo[0].ScoutingConfigVersion,
+		Status:        MatchScoutStatusAccepted,
 	}
 
-	if err = insertMatchScout(ctx, tx, ms); err != nil {
+	n, err := insertMatchScout(ctx, tx, ms)
+	switch {
+	case err == nil && n > 0:
+		// OK.
+	case err == nil && n == 0:
+		return sbd.NewValidationError("account already scouting this match")
+	case errors.Is(err, sbd.ErrAlreadyExists):
+		return sbd.NewValidationError("mode and submode conflicts with other scouts")
+	default:
 		logger.Error("inserting match scout", slog.Any("error", err))
 
 		return errInternal
@@ -279,23 +279,22 @@ func (s *Suite) Test_CreateMatch() {
 	s.Run("success", func() {
 		s.TearDownTest()
 
-		home, err := CreateTeam(context.Background(), NewTeam{
-			Name: "home",
-		}, s.sdb)
+		home, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+			Name:   "home",
+			Shared: true,
+		})
 		s.Require().NoError(err)
 
-		away, err := CreateTeam(context.Background(), NewTeam{
-			Name: "away",
-		}, s.sdb)
+		away, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+			Name:   "away",
+			Shared: true,
+		})
 		s.Require().NoError(err)
 
-		l, err := CreateLeague(context.Background(), NewLeague{
-			Name: "league",
-			TeamUUIDs: []uuid.UUID{
-				home.UUID,
-				away.UUID,
-			},
-		}, s.sdb)
+		l, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+			Name:   "league",
+			Season: testSeason(home.UUID, away.UUID),
+		})
 		s.Require().NoError(err)
 
 		_, err = CreateOrganization(context.Background(), s.sdb, "o1")
@@ -332,23 +331,22 @@ func (s *Suite) Test_CreateMatch() {
 	s.Run("create match with league that is not linked with organization", func() {
 		s.TearDownTest()
 
-		home, err := CreateTeam(context.Background(), NewTeam{
-			Name: "home",
-		}, s.sdb)
+		home, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+			Name:   "home",
+			Shared: true,
+		})
 		s.Require().NoError(err)
 
-		away, err := CreateTeam(context.Background(), NewTeam{
-			Name: "away",
-		}, s.sdb)
+		away, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+			Name:   "away",
+			Shared: true,
+		})
 		s.Require().NoError(err)
 
-		l, err := CreateLeague(context.Background(), NewLeague{
-			Name: "league",
-			TeamUUIDs: []uuid.UUID{
-				home.UUID,
-				away.UUID,
-			},
-		}, s.sdb)
+		l, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+			Name:   "league",
+			Season: testSeason(home.UUID, away.UUID),
+		})
 		s.Require().NoError(err)
 
 		_, err = CreateOrganization(context.Background(), s.sdb, "o2")
@@ -370,25 +368,25 @@ func (s *Suite) Test_CreateMatch() {
 		s.Assert().Equal(sbd.NewNotFoundError("league"), err)
 	})
 
-	s.Run("creating match with a team that does not belong to the league", func() {
+	s.Run("creating match with a team that does not belong to the season", func() {
 		s.TearDownTest()
 
-		home, err := CreateTeam(context.Background(), NewTeam{
-			Name: "home",
-		}, s.sdb)
+		home, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+			Name:   "home",
+			Shared: true,
+		})
 		s.Require().NoError(err)
 
-		away, err := CreateTeam(context.Background(), NewTeam{
-			Name: "away",
-		}, s.sdb)
+		away, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+			Name:   "away",
+			Shared: true,
+		})
 		s.Require().NoError(err)
 
-		l, err := CreateLeague(context.Background(), NewLeague{
-			Name: "league",
-			TeamUUIDs: []uuid.UUID{
-				home.UUID,
-			},
-		}, s.sdb)
+		l, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+			Name:   "league",
+			Season: testSeason(home.UUID),
+		})
 		s.Require().NoError(err)
 
 		_, err = CreateOrganization(context.Background(), s.sdb, "o1")
@@ -407,10 +405,64 @@ func (s *Suite) Test_CreateMatch() {
 		}
 
 		_, err = CreateMatch(context.Background(), s.sdb, "o1", "test_scout", nm)
-		s.Assert().Equal(sbd.NewValidationError("team not found in league"), err)
+		s.Assert().Equal(sbd.NewValidationError("team not found in season"), err)
 	})
 }
 
+func (s *Suite) Test_SelectMatchesPage() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	m1 := s.createMatch("o1")
+	m2 := s.createMatch("o1")
+	m3 := s.createMatch("o1")
+
+	mm, err := SelectMatches(context.Background(), s.sdb, MatchFilter{
+		Active:         true,
+		OrganizationID: "o1",
+		Limit:          2,
+	}, false)
+	s.Require().NoError(err)
+	s.Require().Len(mm, 2)
+	s.Assert().Equal(m1.UUID, mm[0].UUID)
+	s.Assert().Equal(m2.UUID, mm[1].UUID)
+
+	mm, err = SelectMatches(context.Background(), s.sdb, MatchFilter{
+		Active:         true,
+		OrganizationID: "o1",
+		After:          m2.UUID,
+		Limit:          2,
+	}, false)
+	s.Require().NoError(err)
+	s.Require().Len(mm, 1)
+	s.Assert().Equal(m3.UUID, mm[0].UUID)
+}
+
+func (s *Suite) Test_SelectMatchesCurrentSeason() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	m := s.createMatch("o1")
+
+	// The season of the match ran out, no later season started yet.
+	mm, err := SelectMatches(context.Background(), s.sdb, MatchFilter{
+		AnyState:        true,
+		OrganizationID:  "o1",
+		CurrentSeasonAt: time.Now().AddDate(2, 0, 0),
+	}, false)
+	s.Require().NoError(err)
+	s.Require().Len(mm, 1)
+	s.Assert().Equal(m.UUID, mm[0].UUID)
+
+	mm, err = SelectMatches(context.Background(), s.sdb, MatchFilter{
+		AnyState:        true,
+		OrganizationID:  "o1",
+		CurrentSeasonAt: time.Now().Add(-2 * time.Hour),
+	}, false)
+	s.Require().NoError(err)
+	s.Assert().Empty(mm)
+}
+
 func (s *Suite) Test_ScoutMatch() {
 	name := "john"
 	lastName := "mayor"
@@ -429,23 +481,22 @@ func (s *Suite) Test_ScoutMatch() {
 	a, err := OnboardAccount(context.Background(), s.sdb, "o1", clerkUser)
 	s.Require().NoError(err)
 
-	home, err := CreateTeam(context.Background(), NewTeam{
-		Name: "home",
-	}, s.sdb)
+	home, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "home",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	away, err := CreateTeam(context.Background(), NewTeam{
-		Name: "away",
-	}, s.sdb)
+	away, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "away",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	l, err := CreateLeague(context.Background(), NewLeague{
-		Name: "league",
-		TeamUUIDs: []uuid.UUID{
-			home.UUID,
-			away.UUID,
-		},
-	}, s.sdb)
+	l, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
 	s.Require().NoError(err)
 
 	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
@@ -476,6 +527,28 @@ func (s *Suite) Test_ScoutMatch() {
 		squirrel.Eq{"submode": SubmodeAllRules},
 	})
 	s.Assert().Equal(1, cnt)
+
+	b := s.createAccount("o1", "2")
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", b.ID, m.UUID, NewMatchScout{
+		Mode:    ModeDefence,
+		Submode: SubmodeAllRules,
+		Layout:  "Unknown",
+	})
+	s.Assert().Equal(sbd.NewValidationError("layout not found"), err)
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", b.ID, m.UUID, NewMatchScout{
+		Mode:    ModeDefence,
+		Submode: SubmodeAllRules,
+		Layout:  "Basic",
+	})
+	s.Require().NoError(err)
+
+	cnt = s.selectCount("match_scout", squirrel.And{
+		squirrel.Eq{"account_id": b.ID},
+		squirrel.Eq{"layout": "Basic"},
+	})
+	s.Assert().Equal(1, cnt)
 }
 
 func (s *Suite) Test_FinishMatch() {
@@ -496,23 +569,22 @@ func (s *Suite) Test_FinishMatch() {
 	a, err := OnboardAccount(context.Background(), s.sdb, "o1", clerkUser)
 	s.Require().NoError(err)
 
-	home, err := CreateTeam(context.Background(), NewTeam{
-		Name: "home",
-	}, s.sdb)
+	home, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "home",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	away, err := CreateTeam(context.Background(), NewTeam{
-		Name: "away",
-	}, s.sdb)
+	away, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "away",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
-	l, err := CreateLeague(context.Background(), NewLeague{
-		Name: "league",
-		TeamUUIDs: []uuid.UUID{
-			home.UUID,
-			away.UUID,
-		},
-	}, s.sdb)
+	l, _, err := CreateLeague(context.Background(), s.sdb, "", NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
 	s.Require().NoError(err)
 
 	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
@@ -539,21 +611,82 @@ func (s *Suite) Test_FinishMatch() {
 	_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{})
 	s.Require().NoError(err)
 
-	m, err = FinishMatch(context.Background(), s.sdb, "o1", m.UUID, MatchFinishRequest{
+	m, mr, err := FinishMatch(context.Background(), s.sdb, "o1", m.UUID, MatchFinishRequest{
 		HomeScore: 20,
 		AwayScore: 30,
 	})
 	s.Require().NoError(err)
 
+	s.Assert().Equal(uint(0), mr.ScoutedPointsFor.V)
+	s.Assert().False(mr.ScoutedPointsAgainst.Valid)
+	s.Assert().Equal(uint(20), mr.Discrepancy)
+
+	cnt := s.selectCount("match_reconciliation", squirrel.Eq{"match_uuid": m.UUID})
+	s.Assert().Equal(1, cnt)
+
 	s.Assert().Equal(uint(20), m.HomeScore.V)
 	s.Assert().Equal(uint(30), m.AwayScore.V)
 	s.Assert().NotEmpty(m.ModifiedAt)
 	s.Assert().NotNil(m.FinishedAt)
 
-	cnt := s.selectCount("match", squirrel.And{
+	cnt = s.selectCount("match", squirrel.And{
 		squirrel.Eq{"uuid": m.UUID},
 		squirrel.Eq{"home_score": 20},
 		squirrel.Eq{"away_score": 30},
 	})
 	s.Assert().Equal(1, cnt)
 }
+
+func (s *Suite) Test_FinishMatch_Discrepancy() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	o, err := UpdateOrganizationSettings(context.Background(), s.sdb, "o1", OrganizationSettings{
+		MaxScoreDiscrepancy: 5,
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(uint(5), o.MaxScoreDiscrepancy)
+	s.Assert().Equal(uint(1), o.ScoutingConfigVersion)
+
+	a := s.createAccount("o1", "1")
+
+	finish := func(home uint, override bool) (MatchReconciliation, error) {
+		m := s.createMatch("o1")
+
+		err := ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+			Mode:    ModeAttack,
+			Submode: SubmodeAllRules,
+		})
+		s.Require().NoError(err)
+
+		_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{})
+		s.Require().NoError(err)
+
+		_, mr, err := FinishMatch(context.Background(), s.sdb, "o1", m.UUID, MatchFinishRequest{
+			HomeScore: home,
+			AwayScore: 30,
+			Override:  override,
+		})
+
+		return mr, err
+	}
+
+	s.Run("blocked", func() {
+		_, err := finish(20, false)
+		s.Assert().Equal(sbd.NewValidationError("final score differs from scouted points by 20"), err)
+	})
+
+	s.Run("overridden", func() {
+		mr, err := finish(20, true)
+		s.Require().NoError(err)
+		s.Assert().Equal(uint(20), mr.Discrepancy)
+		s.Assert().True(mr.Overridden)
+	})
+
+	s.Run("override within limit", func() {
+		mr, err := finish(3, true)
+		s.Require().NoError(err)
+		s.Assert().Equal(uint(3), mr.Discrepancy)
+		s.Assert().False(mr.Overridden)
+	})
+}
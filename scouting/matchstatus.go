@@ -0,0 +1,272 @@
+package scouting
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+type MatchStatus string
+
+const (
+	MatchStatusScheduled MatchStatus = "scheduled"
+	MatchStatusLive      MatchStatus = "live"
+	MatchStatusPostponed MatchStatus = "postponed"
+	MatchStatusCancelled MatchStatus = "cancelled"
+	MatchStatusFinished  MatchStatus = "finished"
+)
+
+// activeMatchStatuses are statuses of matches that can still be scouted.
+var activeMatchStatuses = []MatchStatus{
+	MatchStatusScheduled,
+	MatchStatusLive,
+	MatchStatusPostponed,
+}
+
+// matchStatusTransitions lists statuses a match can move to. Postponed
+// matches become scheduled again when they are rescheduled.
+var matchStatusTransitions = map[MatchStatus][]MatchStatus{
+	MatchStatusScheduled: {MatchStatusLive, MatchStatusPostponed, MatchStatusCancelled, MatchStatusFinished},
+	MatchStatusLive:      {MatchStatusPostponed, MatchStatusCancelled, MatchStatusFinished},
+	MatchStatusPostponed: {MatchStatusScheduled, MatchStatusCancelled},
+}
+
+func (ms MatchStatus) canTransitionTo(to MatchStatus) bool {
+	return slices.Contains(matchStatusTransitions[ms], to)
+}
+
+// MatchReschedule records a change of match start time.
+type MatchReschedule struct {
+	UUID             uuid.UUID `db:"match_reschedule.uuid"`
+	MatchUUID        uuid.UUID `db:"match_reschedule.match_uuid"`
+	PreviousStartsAt time.Time `db:"match_reschedule.previous_starts_at"`
+	StartsAt         time.Time `db:"match_reschedule.starts_at"`
+	Reason           string    `db:"match_reschedule.reason"`
+	RescheduledBy    string    `db:"match_reschedule.rescheduled_by"`
+	CreatedAt        time.Time `db:"match_reschedule.created_at"`
+}
+
+type NewMatchReschedule struct {
+	StartsAt time.Time `json:"starts_at"`
+	Reason   string    `json:"reason"`
+}
+
+func selectMatchForStatusChange(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, matchUUID uuid.UUID) (Match, error) {
+	mm, err := SelectMatches(ctx, tx, MatchFilter{
+		UUID:           matchUUID,
+		AnyState:       true,
+		OrganizationID: oid,
+	}, true)
+	switch {
+	case err == nil && len(mm) > 0:
+		return mm[0], nil
+	case err == nil && len(mm) == 0:
+		return Match{}, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return Match{}, errInternal
+	}
+}
+
+// UpdateMatchStatus moves the match to live, postponed or cancelled.
+// Matches are finished with FinishMatch and postponed matches are scheduled
+// again with RescheduleMatch. Cancelling a match releases its unfinished
+// scouting slots the same way ReleaseMatchScout does, so the possessions
+// recorded for them are archived with the release. Finished slots keep their
+// reports.
+func UpdateMatchStatus(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, status MatchStatus) (Match, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	switch status {
+	case MatchStatusLive, MatchStatusPostponed, MatchStatusCancelled:
+		// OK.
+	default:
+		return Match{}, sbd.NewValidationError("status must be live, postponed or cancelled")
+	}
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Match{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	m, err := selectMatchForStatusChange(ctx, tx, logger, oid, matchUUID)
+	if err != nil {
+		return Match{}, err
+	}
+
+	if !m.Status.canTransitionTo(status) {
+		return Match{}, sbd.NewValidationError(fmt.Sprintf("%s match cannot be %s", m.Status, status))
+	}
+
+	if status == MatchStatusCancelled {
+		mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+			MatchUUID:  &m.UUID,
+			Statuses:   claimingMatchScoutStatuses,
+			Unfinished: true,
+		})
+		if err != nil {
+			logger.Error("selecting match scouts", slog.Any("error", err))
+
+			return Match{}, errInternal
+		}
+
+		for _, ms := range mss {
+			if err = releaseMatchScout(ctx, tx, logger, ms, aid); err != nil {
+				return Match{}, err
+			}
+		}
+	}
+
+	m.Status = status
+	m.ModifiedAt = time.Now()
+
+	if err = updateMatch(ctx, tx, m); err != nil {
+		logger.Error("updating match", slog.Any("error", err))
+
+		return Match{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Match{}, errInternal
+	}
+
+	return m, nil
+}
+
+// RescheduleMatch moves a scheduled or postponed match to a new start time
+// within its season and schedules it again. The previous start time is kept
+// in the reschedule history.
+func RescheduleMatch(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, nr NewMatchReschedule) (Match, MatchReschedule, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	now := time.Now()
+
+	if nr.StartsAt.Before(now) {
+		return Match{}, MatchReschedule{}, sbd.NewValidationError("starts at cannot be before now")
+	}
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Match{}, MatchReschedule{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	m, err := selectMatchForStatusChange(ctx, tx, logger, oid, matchUUID)
+	if err != nil {
+		return Match{}, MatchReschedule{}, err
+	}
+
+	if m.Status != MatchStatusScheduled && !m.Status.canTransitionTo(MatchStatusScheduled) {
+		return Match{}, MatchReschedule{}, sbd.NewValidationError(fmt.Sprintf("%s match cannot be rescheduled", m.Status))
+	}
+
+	ss, err := SelectSeasons(ctx, tx, SeasonFilter{
+		UUID: m.SeasonUUID,
+	})
+	switch {
+	case err == nil && len(ss) > 0:
+		// OK.
+	case err == nil && len(ss) == 0:
+		return Match{}, MatchReschedule{}, sbd.NewNotFoundError("season")
+	default:
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return Match{}, MatchReschedule{}, errInternal
+	}
+
+	if !ss[0].contains(nr.StartsAt) {
+		return Match{}, MatchReschedule{}, sbd.NewValidationError("match must start within the season")
+	}
+
+	mr := MatchReschedule{
+		UUID:             uuid.Must(uuid.NewV7()),
+		MatchUUID:        m.UUID,
+		PreviousStartsAt: m.StartsAt,
+		StartsAt:         nr.StartsAt,
+		Reason:           nr.Reason,
+		RescheduledBy:    aid,
+		CreatedAt:        now,
+	}
+
+	m.StartsAt = nr.StartsAt
+	m.Status = MatchStatusScheduled
+	m.ModifiedAt = now
+
+	if err = updateMatch(ctx, tx, m); err != nil {
+		logger.Error("updating match", slog.Any("error", err))
+
+		return Match{}, MatchReschedule{}, errInternal
+	}
+
+	if err = insertMatchReschedule(ctx, tx, mr); err != nil {
+		logger.Error("inserting match reschedule", slog.Any("error", err))
+
+		return Match{}, MatchReschedule{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Match{}, MatchReschedule{}, errInternal
+	}
+
+	return m, mr, nil
+}
+
+// SelectMatchReschedules returns the reschedule history of the match, oldest
+// first.
+func SelectMatchReschedules(ctx context.Context, sdb *sqlx.DB, oid string, matchUUID uuid.UUID) ([]MatchReschedule, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		UUID:           matchUUID,
+		AnyState:       true,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return nil, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	mrr, err := selectMatchReschedules(ctx, sdb, matchUUID)
+	if err != nil {
+		logger.Error("selecting match reschedules", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return mrr, nil
+}
@@ -0,0 +1,188 @@
+package scouting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MatchStatus_canTransitionTo(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		From  MatchStatus
+		To    MatchStatus
+		Valid bool
+	}{
+		"scheduled to live": {
+			From:  MatchStatusScheduled,
+			To:    MatchStatusLive,
+			Valid: true,
+		},
+		"live to finished": {
+			From:  MatchStatusLive,
+			To:    MatchStatusFinished,
+			Valid: true,
+		},
+		"postponed to scheduled": {
+			From:  MatchStatusPostponed,
+			To:    MatchStatusScheduled,
+			Valid: true,
+		},
+		"postponed to finished": {
+			From: MatchStatusPostponed,
+			To:   MatchStatusFinished,
+		},
+		"live to scheduled": {
+			From: MatchStatusLive,
+			To:   MatchStatusScheduled,
+		},
+		"cancelled to scheduled": {
+			From: MatchStatusCancelled,
+			To:   MatchStatusScheduled,
+		},
+		"finished to cancelled": {
+			From: MatchStatusFinished,
+			To:   MatchStatusCancelled,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Valid, tc.From.canTransitionTo(tc.To))
+		})
+	}
+}
+
+func (s *Suite) Test_MatchStatus() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+
+	home, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "home",
+	})
+	s.Require().NoError(err)
+
+	away, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "away",
+	})
+	s.Require().NoError(err)
+
+	l, _, err := CreateLeague(context.Background(), s.sdb, "o1", NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
+	s.Require().NoError(err)
+
+	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	nm := NewMatch{
+		LeagueUUID:   l.UUID,
+		HomeTeamUUID: home.UUID,
+		AwayTeamUUID: away.UUID,
+		StartsAt:     time.Now().Add(time.Hour),
+	}
+
+	s.Run("postpone and reschedule", func() {
+		m, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", nm)
+		s.Require().NoError(err)
+		s.Assert().Equal(MatchStatusScheduled, m.Status)
+
+		m, err = UpdateMatchStatus(context.Background(), s.sdb, "o1", "test_scout", m.UUID, MatchStatusPostponed)
+		s.Require().NoError(err)
+		s.Assert().Equal(MatchStatusPostponed, m.Status)
+
+		_, _, err = FinishMatch(context.Background(), s.sdb, "o1", m.UUID, MatchFinishRequest{})
+		s.Assert().Equal(sbd.NewValidationError("postponed match cannot be finished"), err)
+
+		_, _, err = RescheduleMatch(context.Background(), s.sdb, "o1", "test_scout", m.UUID, NewMatchReschedule{
+			StartsAt: time.Now().AddDate(2, 0, 0),
+		})
+		s.Assert().Equal(sbd.NewValidationError("match must start within the season"), err)
+
+		starts := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+		m, _, err = RescheduleMatch(context.Background(), s.sdb, "o1", "test_scout", m.UUID, NewMatchReschedule{
+			StartsAt: starts,
+			Reason:   "weather",
+		})
+		s.Require().NoError(err)
+		s.Assert().Equal(MatchStatusScheduled, m.Status)
+
+		mrr, err := SelectMatchReschedules(context.Background(), s.sdb, "o1", m.UUID)
+		s.Require().NoError(err)
+		s.Require().Len(mrr, 1)
+		s.Assert().True(starts.Equal(mrr[0].StartsAt))
+		s.Assert().Equal("weather", mrr[0].Reason)
+		s.Assert().Equal("test_scout", mrr[0].RescheduledBy)
+	})
+
+	s.Run("cancel releases scouts", func() {
+		m, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", nm)
+		s.Require().NoError(err)
+
+		err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+			Mode:    ModeAttack,
+			Submode: SubmodeAllRules,
+		})
+		s.Require().NoError(err)
+
+		b := s.createAccount("o1", "2")
+
+		err = ScoutMatch(context.Background(), s.sdb, "o1", b.ID, m.UUID, NewMatchScout{
+			Mode:    ModeDefence,
+			Submode: SubmodeAllRules,
+		})
+		s.Require().NoError(err)
+
+		_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", b.ID, m.UUID, ScoutReport{})
+		s.Require().NoError(err)
+
+		_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+			{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+		})
+		s.Require().NoError(err)
+
+		_, err = UpdateMatchStatus(context.Background(), s.sdb, "o1", "test_scout", m.UUID, MatchStatusCancelled)
+		s.Require().NoError(err)
+
+		s.Assert().Equal(0, s.selectCount("match_scout", squirrel.Eq{"match_uuid": m.UUID, "account_id": a.ID}))
+
+		// Finished slots keep their reports.
+		s.Assert().Equal(1, s.selectCount("match_scout", squirrel.Eq{"match_uuid": m.UUID, "account_id": b.ID}))
+
+		maa, err := SelectMatchScoutAudits(context.Background(), s.sdb, "o1", m.UUID)
+		s.Require().NoError(err)
+		s.Require().Len(maa, 1)
+		s.Assert().Equal(MatchScoutActionReleased, maa[0].Action)
+		s.Assert().Equal(a.ID, maa[0].AccountID)
+		s.Assert().Equal(int64(1), maa[0].Possessions)
+		s.Assert().Equal("test_scout", maa[0].PerformedBy)
+
+		mm, err := SelectMatches(context.Background(), s.sdb, MatchFilter{
+			UUID:           m.UUID,
+			Active:         true,
+			OrganizationID: "o1",
+		}, false)
+		s.Require().NoError(err)
+		s.Assert().Empty(mm)
+
+		_, _, err = RescheduleMatch(context.Background(), s.sdb, "o1", "test_scout", m.UUID, NewMatchReschedule{
+			StartsAt: time.Now().Add(48 * time.Hour),
+		})
+		s.Assert().Equal(sbd.NewValidationError("cancelled match cannot be rescheduled"), err)
+
+		_, err = UpdateMatchStatus(context.Background(), s.sdb, "o1", "test_scout", m.UUID, MatchStatusLive)
+		s.Assert().Equal(sbd.NewValidationError("cancelled match cannot be live"), err)
+	})
+}
@@ -0,0 +1,25 @@
+ALTER TABLE match_scout ADD COLUMN IF NOT EXISTS layout_actions JSONB;
+
+-- Match scouts pin the actions of their layout. Existing ones take them from
+-- the config version they claimed the match with, falling back to the current
+-- config for layouts renamed since.
+UPDATE match_scout SET layout_actions = COALESCE((
+    SELECT NULLIF(l.layout->'actions', 'null'::jsonb)
+    FROM match
+    JOIN scouting_config_version AS v ON v.organization_id = match.organization_id
+        AND v.version = match_scout.config_version,
+    jsonb_array_elements(v.scouting_config->'layouts') AS l(layout)
+    WHERE match.uuid = match_scout.match_uuid
+    AND l.layout->>'name' = match_scout.layout
+    LIMIT 1
+), (
+    SELECT NULLIF(l.layout->'actions', 'null'::jsonb)
+    FROM match
+    JOIN organization ON organization.id = match.organization_id,
+    jsonb_array_elements(organization.scouting_config->'layouts') AS l(layout)
+    WHERE match.uuid = match_scout.match_uuid
+    AND l.layout->>'name' = match_scout.layout
+    LIMIT 1
+), '[]'::jsonb)
+WHERE layout IS NOT NULL
+AND layout_actions IS NULL;
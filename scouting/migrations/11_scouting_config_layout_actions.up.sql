@@ -0,0 +1,39 @@
+-- Configs stored before validation was introduced may have layouts listing
+-- actions the config never defined. Such actions are dropped from layouts so
+-- the configs validate again, including the first version snapshots.
+UPDATE organization SET scouting_config = jsonb_set(scouting_config, '{layouts}', COALESCE((
+    SELECT jsonb_agg(
+        CASE WHEN jsonb_typeof(l.layout->'actions') = 'array' THEN
+            jsonb_set(l.layout, '{actions}', COALESCE((
+                SELECT jsonb_agg(a.action ORDER BY a.n)
+                FROM jsonb_array_elements(l.layout->'actions') WITH ORDINALITY AS a(action, n)
+                WHERE a.action IN (
+                    SELECT d.action->'id' FROM jsonb_array_elements(organization.scouting_config->'actions') AS d(action)
+                )
+            ), '[]'::jsonb))
+        ELSE l.layout END
+        ORDER BY l.i
+    )
+    FROM jsonb_array_elements(organization.scouting_config->'layouts') WITH ORDINALITY AS l(layout, i)
+), '[]'::jsonb))
+WHERE jsonb_typeof(scouting_config->'layouts') = 'array'
+AND jsonb_typeof(scouting_config->'actions') = 'array';
+
+UPDATE scouting_config_version SET scouting_config = jsonb_set(scouting_config, '{layouts}', COALESCE((
+    SELECT jsonb_agg(
+        CASE WHEN jsonb_typeof(l.layout->'actions') = 'array' THEN
+            jsonb_set(l.layout, '{actions}', COALESCE((
+                SELECT jsonb_agg(a.action ORDER BY a.n)
+                FROM jsonb_array_elements(l.layout->'actions') WITH ORDINALITY AS a(action, n)
+                WHERE a.action IN (
+                    SELECT d.action->'id' FROM jsonb_array_elements(scouting_config_version.scouting_config->'actions') AS d(action)
+                )
+            ), '[]'::jsonb))
+        ELSE l.layout END
+        ORDER BY l.i
+    )
+    FROM jsonb_array_elements(scouting_config_version.scouting_config->'layouts') WITH ORDINALITY AS l(layout, i)
+), '[]'::jsonb))
+WHERE version = 1
+AND jsonb_typeof(scouting_config->'layouts') = 'array'
+AND jsonb_typeof(scouting_config->'actions') = 'array';
@@ -0,0 +1,25 @@
+ALTER TABLE team ADD COLUMN IF NOT EXISTS organization_id TEXT REFERENCES organization(id);
+
+ALTER TABLE team ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS team_organization_id_idx ON team(organization_id);
+
+-- Teams referenced by a single organization, through its matches or leagues,
+-- belong to it. Teams used by several organizations stay shared.
+UPDATE team SET organization_id = owners.organization_id
+FROM (
+    SELECT refs.team_uuid, MIN(refs.organization_id) AS organization_id
+    FROM (
+        SELECT home_team_uuid AS team_uuid, organization_id FROM match
+        UNION
+        SELECT away_team_uuid AS team_uuid, organization_id FROM match
+        UNION
+        SELECT league_team.team_uuid, organization_league.organization_id
+        FROM league_team
+        JOIN organization_league ON organization_league.league_uuid = league_team.league_uuid
+    ) AS refs
+    GROUP BY refs.team_uuid
+    HAVING COUNT(DISTINCT refs.organization_id) = 1
+) AS owners
+WHERE team.uuid = owners.team_uuid
+AND team.organization_id IS NULL;
@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS season (
+    uuid UUID PRIMARY KEY NOT NULL,
+    league_uuid UUID NOT NULL REFERENCES league(uuid),
+    name TEXT NOT NULL,
+
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS season_league_uuid_idx ON season(league_uuid);
+
+CREATE TABLE IF NOT EXISTS season_team (
+    season_uuid UUID NOT NULL REFERENCES season(uuid),
+    team_uuid UUID NOT NULL REFERENCES team(uuid),
+
+    PRIMARY KEY(season_uuid, team_uuid)
+);
+
+-- Existing leagues get a single season covering all of their matches up to
+-- the end of the current year. Season uuids are version 7 like all other
+-- keys: the league creation time in milliseconds replaces the first 48 bits
+-- of a random uuid and the version nibble is switched from 4 to 7.
+INSERT INTO season (uuid, league_uuid, name, starts_at, ends_at, created_at, modified_at)
+SELECT
+    encode(
+        set_bit(set_bit(
+            overlay(uuid_send(gen_random_uuid())
+                placing substring(int8send(floor(extract(epoch FROM league.created_at) * 1000)::BIGINT) FROM 3)
+                FROM 1 FOR 6),
+        52, 1), 53, 1),
+        'hex'
+    )::UUID,
+    league.uuid,
+    league.name,
+    LEAST(league.created_at, MIN(match.starts_at)),
+    GREATEST(date_trunc('year', CURRENT_TIMESTAMP) + INTERVAL '1 year', MAX(match.starts_at) + INTERVAL '1 day'),
+    league.created_at,
+    league.modified_at
+FROM league
+LEFT JOIN match ON match.league_uuid=league.uuid
+GROUP BY league.uuid;
+
+INSERT INTO season_team (season_uuid, team_uuid)
+SELECT season.uuid, league_team.team_uuid FROM league_team
+INNER JOIN season ON season.league_uuid=league_team.league_uuid
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS league_team;
+
+ALTER TABLE match ADD COLUMN IF NOT EXISTS season_uuid UUID REFERENCES season(uuid);
+
+UPDATE match SET season_uuid=season.uuid FROM season WHERE season.league_uuid=match.league_uuid;
+
+ALTER TABLE match ALTER COLUMN season_uuid SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS match_season_uuid_idx ON match(season_uuid);
@@ -0,0 +1,17 @@
+ALTER TABLE season ADD COLUMN IF NOT EXISTS standings_tie_breakers JSONB NOT NULL DEFAULT '[]';
+
+-- Tie-breakers used to be part of the scouting config. Seasons keep them
+-- when all organizations following the league agree on them.
+UPDATE season SET standings_tie_breakers=tb.tie_breakers
+FROM (
+    SELECT
+        organization_league.league_uuid,
+        MIN(COALESCE(organization.scouting_config->'standings_tie_breakers', '[]')::TEXT)::JSONB AS tie_breakers
+    FROM organization_league
+    INNER JOIN organization ON organization.id=organization_league.organization_id
+    GROUP BY organization_league.league_uuid
+    HAVING COUNT(DISTINCT COALESCE(organization.scouting_config->'standings_tie_breakers', '[]')) = 1
+) AS tb
+WHERE season.league_uuid=tb.league_uuid;
+
+UPDATE organization SET scouting_config = scouting_config - 'standings_tie_breakers';
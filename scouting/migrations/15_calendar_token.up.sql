@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS calendar_token (
+    token_hash TEXT PRIMARY KEY NOT NULL,
+    organization_id TEXT NOT NULL REFERENCES organization(id),
+    account_id TEXT NOT NULL REFERENCES account(id),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (organization_id, account_id)
+);
@@ -0,0 +1,17 @@
+ALTER TABLE match ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'scheduled';
+
+UPDATE match SET status = 'finished' WHERE finished_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS match_status_idx ON match(status);
+
+CREATE TABLE IF NOT EXISTS match_reschedule (
+    uuid UUID PRIMARY KEY NOT NULL,
+    match_uuid UUID NOT NULL REFERENCES match(uuid),
+    previous_starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    rescheduled_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS match_reschedule_match_uuid_idx ON match_reschedule(match_uuid);
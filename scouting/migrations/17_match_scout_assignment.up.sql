@@ -0,0 +1,8 @@
+ALTER TABLE match_scout ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'accepted';
+ALTER TABLE match_scout ADD COLUMN IF NOT EXISTS assigned_by TEXT;
+
+-- Declined assignments no longer hold their mode and submode.
+ALTER TABLE match_scout DROP CONSTRAINT IF EXISTS match_scout_match_uuid_mode_submode_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS match_scout_match_uuid_mode_submode_idx
+    ON match_scout(match_uuid, mode, submode) WHERE status <> 'declined';
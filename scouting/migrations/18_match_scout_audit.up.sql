@@ -0,0 +1,19 @@
+-- Handing over a slot moves its possessions along with it.
+ALTER TABLE possession DROP CONSTRAINT IF EXISTS possession_match_uuid_account_id_fkey;
+ALTER TABLE possession ADD CONSTRAINT possession_match_uuid_account_id_fkey
+    FOREIGN KEY (match_uuid, account_id) REFERENCES match_scout(match_uuid, account_id) ON UPDATE CASCADE;
+
+CREATE TABLE IF NOT EXISTS match_scout_audit (
+    uuid UUID PRIMARY KEY NOT NULL,
+    match_uuid UUID NOT NULL REFERENCES match(uuid),
+    action TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    target_account_id TEXT,
+    mode TEXT NOT NULL,
+    submode TEXT NOT NULL,
+    possessions INTEGER NOT NULL DEFAULT 0,
+    performed_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS match_scout_audit_match_uuid_idx ON match_scout_audit(match_uuid);
@@ -0,0 +1,22 @@
+-- Releasing a slot frees it for other scouts but keeps what was recorded.
+-- Possessions of the released slot are moved here, out of reports, scores
+-- and sequence checks, and linked to the release in the audit. The audit row
+-- is written once the possessions are counted, so the check is deferred.
+CREATE TABLE IF NOT EXISTS released_possession (
+    uuid UUID PRIMARY KEY NOT NULL,
+    match_scout_audit_uuid UUID NOT NULL REFERENCES match_scout_audit(uuid) DEFERRABLE INITIALLY DEFERRED,
+    match_uuid UUID NOT NULL REFERENCES match(uuid),
+    account_id TEXT NOT NULL,
+    action_id TEXT NOT NULL,
+    action_option_id TEXT,
+    outcome_id TEXT NOT NULL,
+    sequence INTEGER NOT NULL,
+    mode TEXT NOT NULL,
+    play TEXT,
+    rule TEXT,
+    game_time INTEGER,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS released_possession_match_scout_audit_uuid_idx ON released_possession(match_scout_audit_uuid);
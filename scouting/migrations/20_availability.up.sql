@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS availability (
+    uuid UUID PRIMARY KEY NOT NULL,
+    organization_id TEXT NOT NULL REFERENCES organization(id),
+    account_id TEXT NOT NULL REFERENCES account(id),
+
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS availability_organization_id_account_id_idx ON availability(organization_id, account_id);
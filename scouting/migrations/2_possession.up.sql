@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS possession (
+    uuid UUID PRIMARY KEY NOT NULL,
+    match_uuid UUID NOT NULL,
+    account_id TEXT NOT NULL,
+    action_id TEXT NOT NULL,
+    action_option_id TEXT,
+    outcome_id TEXT NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (match_uuid, account_id) REFERENCES match_scout(match_uuid, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS possession_match_uuid_idx ON possession(match_uuid);
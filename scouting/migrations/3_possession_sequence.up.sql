@@ -0,0 +1,10 @@
+ALTER TABLE possession ADD COLUMN IF NOT EXISTS sequence INTEGER;
+
+UPDATE possession SET sequence = numbered.sequence FROM (
+    SELECT uuid, ROW_NUMBER() OVER (PARTITION BY match_uuid, account_id ORDER BY uuid) AS sequence
+    FROM possession
+) AS numbered WHERE possession.uuid = numbered.uuid;
+
+ALTER TABLE possession ALTER COLUMN sequence SET NOT NULL;
+
+ALTER TABLE possession ADD CONSTRAINT possession_sequence_key UNIQUE (match_uuid, account_id, sequence);
@@ -0,0 +1,13 @@
+ALTER TABLE possession ADD COLUMN IF NOT EXISTS mode TEXT;
+ALTER TABLE possession ADD COLUMN IF NOT EXISTS play TEXT;
+ALTER TABLE possession ADD COLUMN IF NOT EXISTS rule TEXT;
+
+UPDATE possession SET mode = match_scout.mode
+FROM match_scout
+WHERE match_scout.match_uuid = possession.match_uuid
+    AND match_scout.account_id = possession.account_id
+    AND match_scout.mode <> 'attack_defence';
+
+UPDATE possession SET mode = 'attack' WHERE mode IS NULL;
+
+ALTER TABLE possession ALTER COLUMN mode SET NOT NULL;
@@ -0,0 +1,17 @@
+ALTER TABLE match ADD COLUMN IF NOT EXISTS scouted_team_uuid UUID REFERENCES team(uuid);
+
+UPDATE match SET scouted_team_uuid = home_team_uuid WHERE scouted_team_uuid IS NULL;
+
+ALTER TABLE match ALTER COLUMN scouted_team_uuid SET NOT NULL;
+
+CREATE TABLE IF NOT EXISTS match_reconciliation (
+    match_uuid UUID PRIMARY KEY NOT NULL REFERENCES match(uuid),
+    scouted_points_for INTEGER,
+    scouted_points_against INTEGER,
+    points_for INTEGER NOT NULL,
+    points_against INTEGER NOT NULL,
+    discrepancy INTEGER NOT NULL,
+    overridden BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
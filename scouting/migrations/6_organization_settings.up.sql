@@ -0,0 +1,6 @@
+ALTER TABLE organization ADD COLUMN IF NOT EXISTS max_score_discrepancy INTEGER NOT NULL DEFAULT 0;
+
+-- The discrepancy limit used to be part of the scouting config.
+UPDATE organization SET
+    max_score_discrepancy = COALESCE((scouting_config->>'max_score_discrepancy')::INTEGER, 0),
+    scouting_config = scouting_config - 'max_score_discrepancy';
@@ -0,0 +1 @@
+ALTER TABLE possession ADD COLUMN IF NOT EXISTS game_time INTEGER;
@@ -0,0 +1 @@
+ALTER TABLE match_scout ADD COLUMN IF NOT EXISTS layout TEXT;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS scouting_config_version (
+    organization_id TEXT NOT NULL REFERENCES organization(id),
+    version INTEGER NOT NULL,
+    scouting_config JSONB NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (organization_id, version)
+);
+
+ALTER TABLE organization ADD COLUMN IF NOT EXISTS scouting_config_version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE organization ALTER COLUMN scouting_config_version DROP DEFAULT;
+
+INSERT INTO scouting_config_version (organization_id, version, scouting_config, created_at)
+SELECT id, scouting_config_version, scouting_config, modified_at FROM organization
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE match_scout ADD COLUMN IF NOT EXISTS config_version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE match_scout ALTER COLUMN config_version DROP DEFAULT;
@@ -11,39 +11,73 @@ import (
 )
 
 type Organization struct {
-	ID             string         `db:"organization.id"`
-	ScoutingConfig ScoutingConfig `db:"organization.scouting_config"`
-	Name           string         `db:"organizations.name"`
-	CreatedAt      time.Time      `db:"organization.created_at"`
-	ModifiedAt     time.Time      `db:"organization.modified_at"`
+	ID                    string         `db:"organization.id"`
+	ScoutingConfig        ScoutingConfig `db:"organization.scouting_config"`
+	ScoutingConfigVersion uint           `db:"organization.scouting_config_version"`
+	MaxScoreDiscrepancy   uint           `db:"organization.max_score_discrepancy"`
+	Name                  string         `db:"organizations.name"`
+	CreatedAt             time.Time      `db:"organization.created_at"`
+	ModifiedAt            time.Time      `db:"organization.modified_at"`
 }
 
 type NewOrganization struct {
 	ID string
 }
 
+// OrganizationSettings are organization wide policies kept out of the
+// versioned scouting config.
+type OrganizationSettings struct {
+	// MaxScoreDiscrepancy blocks finishing a match when the final score
+	// differs from scouted points by more than this. Zero disables it.
+	MaxScoreDiscrepancy uint `json:"max_score_discrepancy"`
+}
+
 type OrganizationFilter struct {
 	IDs []string
 }
 
 func CreateOrganization(ctx context.Context, sdb *sqlx.DB, id string) (Organization, error) {
+	logger := slog.With(slog.String("organization_id", id))
+
 	tnow := time.Now()
 
 	o := Organization{
-		ID:             id,
-		ScoutingConfig: DefaultScoutingConfig,
-		CreatedAt:      tnow,
-		ModifiedAt:     tnow,
+		ID:                    id,
+		ScoutingConfig:        DefaultScoutingConfig,
+		ScoutingConfigVersion: 1,
+		CreatedAt:             tnow,
+		ModifiedAt:            tnow,
 	}
 
-	err := insertOrganization(ctx, sdb, o)
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	err = insertOrganization(ctx, tx, o)
 	switch {
 	case err == nil:
 		// OK.
 	case errors.Is(err, sbd.ErrAlreadyExists):
 		return Organization{}, err
 	default:
-		slog.Error("inserting organization", slog.Any("error", err))
+		logger.Error("inserting organization", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	if err = insertScoutingConfigVersion(ctx, tx, o.configVersion()); err != nil {
+		logger.Error("inserting scouting config version", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
 
 		return Organization{}, errInternal
 	}
@@ -51,6 +85,142 @@ func CreateOrganization(ctx context.Context, sdb *sqlx.DB, id string) (Organizat
 	return o, nil
 }
 
+// configVersion snapshots the current scouting config of the organization.
+func (o *Organization) configVersion() ScoutingConfigVersion {
+	return ScoutingConfigVersion{
+		OrganizationID: o.ID,
+		Version:        o.ScoutingConfigVersion,
+		ScoutingConfig: o.ScoutingConfig,
+		CreatedAt:      o.ModifiedAt,
+	}
+}
+
 func SelectOrganizations(ctx context.Context, sdb *sqlx.DB, f OrganizationFilter) ([]Organization, error) {
-	return selectOrganizations(ctx, sdb, f)
+	return selectOrganizations(ctx, sdb, f, false)
+}
+
+// UpdateOrganizationSettings replaces the settings of the organization.
+func UpdateOrganizationSettings(ctx context.Context, sdb *sqlx.DB, oid string, settings OrganizationSettings) (Organization, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	oo, err := selectOrganizations(ctx, tx, OrganizationFilter{
+		IDs: []string{oid},
+	}, true)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return Organization{}, sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	o := oo[0]
+	o.MaxScoreDiscrepancy = settings.MaxScoreDiscrepancy
+	o.ModifiedAt = time.Now()
+
+	if err = updateOrganization(ctx, tx, o); err != nil {
+		logger.Error("updating organization", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	return o, nil
+}
+
+// UpdateScoutingConfig replaces the scouting config of the organization.
+// Layouts used by unfinished match scouts cannot be removed.
+func UpdateScoutingConfig(ctx context.Context, sdb *sqlx.DB, oid string, cfg ScoutingConfig) (Organization, error) {
+	return changeScoutingConfig(ctx, sdb, oid, func(_ *sqlx.Tx, curr *ScoutingConfig) error {
+		*curr = cfg
+
+		return nil
+	})
+}
+
+// changeScoutingConfig applies the change to the organization scouting
+// config and stores the result as a new version if it is still valid. The
+// change may return validation or not found errors, which are returned as is.
+// Layouts used by unfinished match scouts cannot be removed. The organization
+// row is locked so concurrent changes are applied one after another.
+func changeScoutingConfig(ctx context.Context, sdb *sqlx.DB, oid string, change func(tx *sqlx.Tx, cfg *ScoutingConfig) error) (Organization, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	oo, err := selectOrganizations(ctx, tx, OrganizationFilter{
+		IDs: []string{oid},
+	}, true)
+	switch {
+	case err == nil && len(oo) > 0:
+		// OK.
+	case err == nil && len(oo) == 0:
+		return Organization{}, sbd.NewNotFoundError("organization")
+	default:
+		logger.Error("selecting organizations", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	o := oo[0]
+	prev := layoutNames(o.ScoutingConfig)
+
+	if err = change(tx, &o.ScoutingConfig); err != nil {
+		return Organization{}, err
+	}
+
+	if err = o.ScoutingConfig.Validate(); err != nil {
+		return Organization{}, sbd.NewValidationError(err.Error())
+	}
+
+	if err = checkRemovedLayouts(ctx, tx, logger, oid, prev, o.ScoutingConfig); err != nil {
+		return Organization{}, err
+	}
+
+	o.ScoutingConfigVersion++
+	o.ModifiedAt = time.Now()
+
+	if err = updateOrganization(ctx, tx, o); err != nil {
+		logger.Error("updating organization", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	if err = insertScoutingConfigVersion(ctx, tx, o.configVersion()); err != nil {
+		logger.Error("inserting scouting config version", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Organization{}, errInternal
+	}
+
+	return o, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/sportsbydata/backend/sbd"
 )
 
 func (s *Suite) Test_CreateOrganization() {
@@ -21,3 +22,77 @@ func (s *Suite) Test_CreateOrganization() {
 
 	s.Assert().Equal(1, cnt)
 }
+
+func (s *Suite) Test_UpdateScoutingConfig() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "id")
+	s.Require().NoError(err)
+
+	cfg := ScoutingConfig{
+		Actions:  []Action{{ID: "w5"}},
+		Outcomes: []Outcome{{ID: "2p", Points: 2, EndedInShot: true}},
+		Layouts:  []Layout{{Name: "Basic", Actions: []string{"w5"}}},
+	}
+
+	o, err := UpdateScoutingConfig(context.Background(), s.sdb, "id", cfg)
+	s.Require().NoError(err)
+	s.Assert().Equal(cfg, o.ScoutingConfig)
+
+	oo, err := SelectOrganizations(context.Background(), s.sdb, OrganizationFilter{
+		IDs: []string{"id"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(oo, 1)
+	s.Assert().Equal(cfg, oo[0].ScoutingConfig)
+
+	cfg.Layouts[0].Actions = []string{"tw5"}
+
+	_, err = UpdateScoutingConfig(context.Background(), s.sdb, "id", cfg)
+	s.Assert().Equal(sbd.NewValidationError(`layouts[0].actions[0]: unknown action "tw5"`), err)
+
+	_, err = UpdateScoutingConfig(context.Background(), s.sdb, "unknown", ScoutingConfig{})
+	s.Assert().Equal(sbd.NewNotFoundError("organization"), err)
+
+	a := s.createAccount("id", "1")
+	m := s.createMatch("id")
+
+	err = ScoutMatch(context.Background(), s.sdb, "id", a.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+		Layout:  "Basic",
+	})
+	s.Require().NoError(err)
+
+	_, err = UpdateScoutingConfig(context.Background(), s.sdb, "id", ScoutingConfig{
+		Actions:  []Action{{ID: "w5"}},
+		Outcomes: []Outcome{{ID: "2p", Points: 2, EndedInShot: true}},
+	})
+	s.Assert().Equal(sbd.NewValidationError(`layout "Basic" is used by active match scouts`), err)
+}
+
+func (s *Suite) Test_LegacyScoutingConfig() {
+	s.createLegacyOrganization("o1")
+
+	_, err := CreateLayout(context.Background(), s.sdb, "o1", Layout{Name: "Custom", Actions: []string{"w5"}})
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), `layouts[1].actions[3]: unknown action "tw5"`)
+
+	s.migrateLegacy()
+
+	vv, err := SelectScoutingConfigVersions(context.Background(), s.sdb, ScoutingConfigVersionFilter{
+		OrganizationID: "o1",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(vv, 1)
+	s.Assert().Equal(DefaultScoutingConfig, vv[0].ScoutingConfig)
+
+	l, err := CreateLayout(context.Background(), s.sdb, "o1", Layout{Name: "Custom", Actions: []string{"w5"}})
+	s.Require().NoError(err)
+	s.Assert().Equal("Custom", l.Name)
+
+	oo, err := SelectOrganizations(context.Background(), s.sdb, OrganizationFilter{
+		IDs: []string{"o1"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(oo, 1)
+	s.Assert().Equal(DefaultScoutingConfig.Layouts[1], oo[0].ScoutingConfig.Layouts[1])
+}
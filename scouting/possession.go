@@ -0,0 +1,377 @@
+package scouting
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+type Possession struct {
+	UUID           uuid.UUID          `db:"possession.uuid"`
+	MatchUUID      uuid.UUID          `db:"possession.match_uuid"`
+	AccountID      string             `db:"possession.account_id"`
+	ActionID       string             `db:"possession.action_id"`
+	ActionOptionID null.Value[string] `db:"possession.action_option_id"`
+	OutcomeID      string             `db:"possession.outcome_id"`
+	Sequence       uint               `db:"possession.sequence"`
+	Mode           Mode               `db:"possession.mode"`
+	Play           null.Value[string] `db:"possession.play"`
+	Rule           null.Value[string] `db:"possession.rule"`
+	GameTime       null.Value[uint]   `db:"possession.game_time"`
+	CreatedAt      time.Time          `db:"possession.created_at"`
+}
+
+type NewPossession struct {
+	Sequence       uint             `json:"sequence"`
+	ActionID       string           `json:"action_id"`
+	ActionOptionID string           `json:"action_option_id"`
+	OutcomeID      string           `json:"outcome_id"`
+	Mode           Mode             `json:"mode"`
+	Play           string           `json:"play"`
+	Rule           string           `json:"rule"`
+	GameTime       null.Value[uint] `json:"game_time"`
+}
+
+func (np *NewPossession) ToPossession(ms MatchScout) Possession {
+	return Possession{
+		UUID:           uuid.Must(uuid.NewV7()),
+		MatchUUID:      ms.MatchUUID,
+		AccountID:      ms.AccountID,
+		ActionID:       np.ActionID,
+		ActionOptionID: null.NewValue(np.ActionOptionID, np.ActionOptionID != ""),
+		OutcomeID:      np.OutcomeID,
+		Sequence:       np.Sequence,
+		Mode:           np.Mode,
+		Play:           null.NewValue(np.Play, np.Play != ""),
+		Rule:           null.NewValue(np.Rule, np.Rule != ""),
+		GameTime:       np.GameTime,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func (np *NewPossession) Validate(cfg ScoutingConfig) error {
+	if np.ActionID == "" {
+		return errors.New("action is required")
+	}
+
+	a, ok := cfg.action(np.ActionID)
+	if !ok {
+		return fmt.Errorf("unknown action %q", np.ActionID)
+	}
+
+	if np.ActionOptionID != "" && !a.hasOption(np.ActionOptionID) {
+		return fmt.Errorf("unknown option %q for action %q", np.ActionOptionID, np.ActionID)
+	}
+
+	if np.OutcomeID == "" {
+		return errors.New("outcome is required")
+	}
+
+	if _, ok := cfg.outcome(np.OutcomeID); !ok {
+		return fmt.Errorf("unknown outcome %q", np.OutcomeID)
+	}
+
+	return nil
+}
+
+// inScope checks that the possession stays within the slice of the game
+// claimed by the match scout, so that merged reports never count the same
+// possession twice.
+func (np *NewPossession) inScope(ms MatchScout) error {
+	switch np.Mode {
+	case ModeAttack, ModeDefence:
+		// OK.
+	default:
+		return errors.New("possession mode must be attack or defence")
+	}
+
+	if ms.Mode != ModeAttackDefence && ms.Mode != np.Mode {
+		return fmt.Errorf("%s possession outside of %s scouting", np.Mode, ms.Mode)
+	}
+
+	switch ms.Submode {
+	case SubmodePlays:
+		if np.Play == "" {
+			return errors.New("play call is required for plays submode")
+		}
+	case SubmodeOurRules, SubmodeNotOurRules:
+		if np.Rule == "" {
+			return fmt.Errorf("rule reference is required for %s submode", ms.Submode)
+		}
+	}
+
+	return nil
+}
+
+// inLayout checks that the possession only uses actions of the layout the
+// match scout picked when claiming the match.
+func (np *NewPossession) inLayout(ms MatchScout) error {
+	if !ms.Layout.Valid {
+		return nil
+	}
+
+	if !slices.Contains(ms.LayoutActions, np.ActionID) {
+		return fmt.Errorf("action %q not in layout %q", np.ActionID, ms.Layout.V)
+	}
+
+	return nil
+}
+
+// recorded reports whether p holds the same possession.
+func (np *NewPossession) recorded(p Possession) bool {
+	return np.ActionID == p.ActionID &&
+		np.ActionOptionID == p.ActionOptionID.V &&
+		np.OutcomeID == p.OutcomeID &&
+		np.Mode == p.Mode &&
+		np.Play == p.Play.V &&
+		np.Rule == p.Rule.V &&
+		np.GameTime == p.GameTime
+}
+
+// numberPossessions assigns sequences in array order to possessions sent
+// without one. Unsequenced reports are only accepted while nothing has been
+// recorded for the slot, otherwise possessions appended live would be stored
+// twice.
+func numberPossessions(pp []NewPossession, recorded []Possession) ([]NewPossession, error) {
+	var unsequenced int
+
+	for _, np := range pp {
+		if np.Sequence == 0 {
+			unsequenced++
+		}
+	}
+
+	switch {
+	case unsequenced == 0:
+		return pp, nil
+	case unsequenced < len(pp):
+		return nil, errors.New("either all or no possessions must have a sequence")
+	case len(recorded) > 0:
+		return nil, errors.New("possessions were already recorded, sequences are required")
+	}
+
+	numbered := make([]NewPossession, len(pp))
+
+	for i, np := range pp {
+		np.Sequence = uint(i) + 1
+		numbered[i] = np
+	}
+
+	return numbered, nil
+}
+
+// unrecordedPossessions returns possessions whose sequence was not recorded
+// yet. A recorded sequence must hold the same possession, so retries are
+// accepted while conflicting data is rejected.
+func unrecordedPossessions(pp []NewPossession, recorded []Possession) ([]NewPossession, error) {
+	seqs := make(map[uint]Possession, len(recorded))
+
+	for _, p := range recorded {
+		seqs[p.Sequence] = p
+	}
+
+	var unrecorded []NewPossession
+
+	for i, np := range pp {
+		p, ok := seqs[np.Sequence]
+		switch {
+		case !ok:
+			unrecorded = append(unrecorded, np)
+		case !np.recorded(p):
+			return nil, fmt.Errorf("possession %d: sequence %d already recorded with different data", i, np.Sequence)
+		}
+	}
+
+	return unrecorded, nil
+}
+
+type PossessionFilter struct {
+	MatchUUID           *uuid.UUID
+	MatchUUIDs          []uuid.UUID
+	AccountID           *string
+	MatchOrganizationID *string
+}
+
+type ScoutReport struct {
+	Possessions []NewPossession `json:"possessions"`
+}
+
+// Validate checks possessions against the config version and layout actions
+// pinned by the match scout.
+func (sr *ScoutReport) Validate(cfg ScoutingConfig, ms MatchScout) error {
+	return validatePossessions(cfg, ms, sr.Possessions)
+}
+
+// validatePossessions checks possessions of a batch. Sequences start at 1,
+// either all possessions carry one or none of them do.
+func validatePossessions(cfg ScoutingConfig, ms MatchScout, pp []NewPossession) error {
+	seqs := make(map[uint]struct{}, len(pp))
+
+	sequenced := len(pp) > 0 && pp[0].Sequence > 0
+
+	for i, np := range pp {
+		if (np.Sequence > 0) != sequenced {
+			return fmt.Errorf("possession %d: either all or no possessions must have a sequence", i)
+		}
+
+		if sequenced {
+			if _, ok := seqs[np.Sequence]; ok {
+				return fmt.Errorf("possession %d: duplicate sequence %d", i, np.Sequence)
+			}
+
+			seqs[np.Sequence] = struct{}{}
+		}
+
+		if err := np.Validate(cfg); err != nil {
+			return fmt.Errorf("possession %d: %w", i, err)
+		}
+
+		if err := np.inScope(ms); err != nil {
+			return fmt.Errorf("possession %d: %w", i, err)
+		}
+
+		if err := np.inLayout(ms); err != nil {
+			return fmt.Errorf("possession %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// AppendPossessions records possessions for the account's open match scout.
+// Every possession needs a sequence. Possessions with a sequence that was
+// already recorded are ignored, so clients can safely retry the same batch,
+// unless they conflict with the recorded one.
+func AppendPossessions(ctx context.Context, sdb *sqlx.DB, oid, aid string, matchUUID uuid.UUID, pp []NewPossession) ([]Possession, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	defer tx.Rollback()
+
+	mm, err := SelectMatches(ctx, tx, MatchFilter{
+		UUID:           matchUUID,
+		Active:         true,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return nil, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+		MatchUUID:           &matchUUID,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	var ms *MatchScout
+
+	for _, m := range mss {
+		if m.AccountID == aid {
+			ms = &m
+
+			break
+		}
+	}
+
+	if ms == nil {
+		return nil, sbd.NewValidationError("match scout not found")
+	}
+
+	if ms.FinishedAt.Valid {
+		return nil, sbd.NewValidationError("match scout already finished")
+	}
+
+	vv, err := selectScoutingConfigVersions(ctx, tx, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+		Versions:       []uint{ms.ConfigVersion},
+	})
+	switch {
+	case err == nil && len(vv) > 0:
+		// OK.
+	case err == nil && len(vv) == 0:
+		return nil, sbd.NewNotFoundError("scouting config version")
+	default:
+		logger.Error("selecting scouting config versions", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	for i, np := range pp {
+		if np.Sequence == 0 {
+			return nil, sbd.NewValidationError(fmt.Sprintf("possession %d: sequence is required", i))
+		}
+	}
+
+	if err = validatePossessions(vv[0].ScoutingConfig, *ms, pp); err != nil {
+		return nil, sbd.NewValidationError(err.Error())
+	}
+
+	recorded, err := SelectPossessions(ctx, tx, PossessionFilter{
+		MatchUUID: &matchUUID,
+		AccountID: &aid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	pp, err = unrecordedPossessions(pp, recorded)
+	if err != nil {
+		return nil, sbd.NewValidationError(err.Error())
+	}
+
+	for _, np := range pp {
+		p := np.ToPossession(*ms)
+
+		if err = insertPossession(ctx, tx, p); err != nil {
+			logger.Error("inserting possession", slog.Any("error", err))
+
+			return nil, errInternal
+		}
+
+		recorded = append(recorded, p)
+	}
+
+	slices.SortFunc(recorded, func(a, b Possession) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return recorded, nil
+}
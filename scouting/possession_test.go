@@ -0,0 +1,447 @@
+package scouting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null/v5"
+	"github.com/sportsbydata/backend/sbd"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScoutReport_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Report ScoutReport
+		Error  string
+	}{
+		"valid with option": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{ActionID: "w5", ActionOptionID: "roll", OutcomeID: "o2", Mode: ModeAttack},
+				},
+			},
+		},
+		"valid without option": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{ActionID: "zone", OutcomeID: "steal / to", Mode: ModeAttack},
+				},
+			},
+		},
+		"unknown action": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+					{ActionID: "w6", OutcomeID: "o2", Mode: ModeAttack},
+				},
+			},
+			Error: `possession 1: unknown action "w6"`,
+		},
+		"mixed sequences": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+					{ActionID: "zone", OutcomeID: "o2", Mode: ModeAttack},
+				},
+			},
+			Error: "possession 1: either all or no possessions must have a sequence",
+		},
+		"duplicate sequence": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+					{Sequence: 1, ActionID: "zone", OutcomeID: "o2", Mode: ModeAttack},
+				},
+			},
+			Error: "possession 1: duplicate sequence 1",
+		},
+		"unknown option": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{ActionID: "w5", ActionOptionID: "weak hand", OutcomeID: "o2", Mode: ModeAttack},
+				},
+			},
+			Error: `possession 0: unknown option "weak hand" for action "w5"`,
+		},
+		"unknown outcome": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{ActionID: "w5", OutcomeID: "o4", Mode: ModeAttack},
+				},
+			},
+			Error: `possession 0: unknown outcome "o4"`,
+		},
+		"missing outcome": {
+			Report: ScoutReport{
+				Possessions: []NewPossession{
+					{ActionID: "w5", Mode: ModeAttack},
+				},
+			},
+			Error: "possession 0: outcome is required",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Report.Validate(DefaultScoutingConfig, MatchScout{
+				Mode:    ModeAttack,
+				Submode: SubmodeAllRules,
+			})
+			if tc.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, tc.Error)
+		})
+	}
+}
+
+func Test_NewPossession_inScope(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Scout      MatchScout
+		Possession NewPossession
+		Error      string
+	}{
+		"attack possession by attack scout": {
+			Scout:      MatchScout{Mode: ModeAttack, Submode: SubmodeAnyRules},
+			Possession: NewPossession{Mode: ModeAttack},
+		},
+		"defence possession by attack scout": {
+			Scout:      MatchScout{Mode: ModeAttack, Submode: SubmodeAnyRules},
+			Possession: NewPossession{Mode: ModeDefence},
+			Error:      "defence possession outside of attack scouting",
+		},
+		"attack possession by defence scout": {
+			Scout:      MatchScout{Mode: ModeDefence, Submode: SubmodeAllRules},
+			Possession: NewPossession{Mode: ModeAttack},
+			Error:      "attack possession outside of defence scouting",
+		},
+		"both sides by attack_defence scout": {
+			Scout:      MatchScout{Mode: ModeAttackDefence, Submode: SubmodeAnyRules},
+			Possession: NewPossession{Mode: ModeDefence},
+		},
+		"missing mode": {
+			Scout:      MatchScout{Mode: ModeAttackDefence, Submode: SubmodeAnyRules},
+			Possession: NewPossession{},
+			Error:      "possession mode must be attack or defence",
+		},
+		"attack_defence possession mode": {
+			Scout:      MatchScout{Mode: ModeAttackDefence, Submode: SubmodeAnyRules},
+			Possession: NewPossession{Mode: ModeAttackDefence},
+			Error:      "possession mode must be attack or defence",
+		},
+		"plays without play call": {
+			Scout:      MatchScout{Mode: ModeAttack, Submode: SubmodePlays},
+			Possession: NewPossession{Mode: ModeAttack},
+			Error:      "play call is required for plays submode",
+		},
+		"plays with play call": {
+			Scout:      MatchScout{Mode: ModeAttack, Submode: SubmodePlays},
+			Possession: NewPossession{Mode: ModeAttack, Play: "horns"},
+		},
+		"our rules without rule": {
+			Scout:      MatchScout{Mode: ModeDefence, Submode: SubmodeOurRules},
+			Possession: NewPossession{Mode: ModeDefence},
+			Error:      "rule reference is required for our_rules submode",
+		},
+		"not our rules without rule": {
+			Scout:      MatchScout{Mode: ModeDefence, Submode: SubmodeNotOurRules},
+			Possession: NewPossession{Mode: ModeDefence},
+			Error:      "rule reference is required for not_our_rules submode",
+		},
+		"our rules with rule": {
+			Scout:      MatchScout{Mode: ModeDefence, Submode: SubmodeOurRules},
+			Possession: NewPossession{Mode: ModeDefence, Rule: "ice"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Possession.inScope(tc.Scout)
+			if tc.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, tc.Error)
+		})
+	}
+}
+
+func Test_NewPossession_inLayout(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Scout      MatchScout
+		Possession NewPossession
+		Error      string
+	}{
+		"no layout": {
+			Scout:      MatchScout{},
+			Possession: NewPossession{ActionID: "w5"},
+		},
+		"action in layout": {
+			Scout: MatchScout{
+				Layout:        null.NewValue("Basic", true),
+				LayoutActions: DefaultScoutingConfig.layoutActions("Basic"),
+			},
+			Possession: NewPossession{ActionID: "w5"},
+		},
+		"action not in layout": {
+			Scout: MatchScout{
+				Layout:        null.NewValue("Basic", true),
+				LayoutActions: DefaultScoutingConfig.layoutActions("Basic"),
+			},
+			Possession: NewPossession{ActionID: "unknown"},
+			Error:      `action "unknown" not in layout "Basic"`,
+		},
+		"action removed from layout after claiming": {
+			Scout: MatchScout{
+				Layout:        null.NewValue("Basic", true),
+				LayoutActions: LayoutActions{"1x1"},
+			},
+			Possession: NewPossession{ActionID: "w5"},
+			Error:      `action "w5" not in layout "Basic"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Possession.inLayout(tc.Scout)
+			if tc.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, tc.Error)
+		})
+	}
+}
+
+func (s *Suite) Test_SubmitScoutReport() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	m := s.createMatch("o1")
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{
+		Possessions: []NewPossession{
+			{ActionID: "unknown", OutcomeID: "o2", Mode: ModeAttack},
+		},
+	})
+	s.Assert().Equal(sbd.NewValidationError(`possession 0: unknown action "unknown"`), err)
+
+	ms, err := SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{
+		Possessions: []NewPossession{
+			{ActionID: "w5", ActionOptionID: "roll", OutcomeID: "o2", Mode: ModeAttack},
+			{ActionID: "1x1", OutcomeID: "x3", Mode: ModeAttack},
+		},
+	})
+	s.Require().NoError(err)
+	s.Assert().True(ms.FinishedAt.Valid)
+
+	pp, err := SelectPossessions(context.Background(), s.sdb, PossessionFilter{
+		MatchUUID: &m.UUID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(pp, 2)
+
+	s.Assert().Equal("w5", pp[0].ActionID)
+	s.Assert().Equal("roll", pp[0].ActionOptionID.V)
+	s.Assert().Equal("o2", pp[0].OutcomeID)
+	s.Assert().Equal("1x1", pp[1].ActionID)
+	s.Assert().False(pp[1].ActionOptionID.Valid)
+
+	cnt := s.selectCount("possession", squirrel.Eq{"account_id": a.ID})
+	s.Assert().Equal(2, cnt)
+}
+
+func (s *Suite) Test_AppendPossessions() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	m := s.createMatch("o1")
+
+	_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+	})
+	s.Assert().Equal(sbd.NewValidationError("match scout not found"), err)
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	pp, err := AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+		{Sequence: 2, ActionID: "zone", OutcomeID: "x3", Mode: ModeAttack},
+	})
+	s.Require().NoError(err)
+	s.Assert().Len(pp, 2)
+
+	// Retrying an already recorded sequence must not duplicate it.
+	pp, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 2, ActionID: "zone", OutcomeID: "x3", Mode: ModeAttack},
+		{Sequence: 3, ActionID: "fb", OutcomeID: "o3", Mode: ModeAttack},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(pp, 3)
+	s.Assert().Equal(uint(3), pp[2].Sequence)
+
+	_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 3, ActionID: "zone", OutcomeID: "o3", Mode: ModeAttack},
+	})
+	s.Assert().Equal(sbd.NewValidationError("possession 0: sequence 3 already recorded with different data"), err)
+
+	_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{ActionID: "zone", OutcomeID: "o3", Mode: ModeAttack},
+	})
+	s.Assert().Equal(sbd.NewValidationError("possession 0: sequence is required"), err)
+
+	_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{
+		Possessions: []NewPossession{
+			{Sequence: 2, ActionID: "fb", OutcomeID: "o3", Mode: ModeAttack},
+		},
+	})
+	s.Assert().Equal(sbd.NewValidationError("possession 0: sequence 2 already recorded with different data"), err)
+
+	// Reports without sequences would store appended possessions twice.
+	_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{
+		Possessions: []NewPossession{
+			{ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+			{ActionID: "zone", OutcomeID: "x3", Mode: ModeAttack},
+			{ActionID: "fb", OutcomeID: "o3", Mode: ModeAttack},
+			{ActionID: "1x1", OutcomeID: "x3", Mode: ModeAttack},
+		},
+	})
+	s.Assert().Equal(sbd.NewValidationError("possessions were already recorded, sequences are required"), err)
+
+	_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", a.ID, m.UUID, ScoutReport{
+		Possessions: []NewPossession{
+			{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+			{Sequence: 4, ActionID: "1x1", OutcomeID: "x3", Mode: ModeAttack},
+		},
+	})
+	s.Require().NoError(err)
+
+	_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 5, ActionID: "fb", OutcomeID: "o3", Mode: ModeAttack},
+	})
+	s.Assert().Equal(sbd.NewValidationError("match scout already finished"), err)
+
+	pp, err = SelectPossessions(context.Background(), s.sdb, PossessionFilter{
+		MatchUUID: &m.UUID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(pp, 4)
+	s.Assert().Equal("1x1", pp[3].ActionID)
+	s.Assert().Equal(uint(4), pp[3].Sequence)
+}
+
+func Test_numberPossessions(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Possessions []NewPossession
+		Recorded    []Possession
+		Result      []NewPossession
+		Error       string
+	}{
+		"unsequenced": {
+			Possessions: []NewPossession{{ActionID: "w5"}, {ActionID: "zone"}},
+			Result:      []NewPossession{{Sequence: 1, ActionID: "w5"}, {Sequence: 2, ActionID: "zone"}},
+		},
+		"sequenced": {
+			Possessions: []NewPossession{{Sequence: 7, ActionID: "w5"}},
+			Recorded:    []Possession{{Sequence: 1}},
+			Result:      []NewPossession{{Sequence: 7, ActionID: "w5"}},
+		},
+		"mixed": {
+			Possessions: []NewPossession{{Sequence: 1, ActionID: "w5"}, {ActionID: "zone"}},
+			Error:       "either all or no possessions must have a sequence",
+		},
+		"unsequenced after live append": {
+			Possessions: []NewPossession{{ActionID: "w5"}},
+			Recorded:    []Possession{{Sequence: 1}},
+			Error:       "possessions were already recorded, sequences are required",
+		},
+	}
+
+	for cn, c := range cases {
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			pp, err := numberPossessions(c.Possessions, c.Recorded)
+			if c.Error != "" {
+				assert.EqualError(t, err, c.Error)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.Result, pp)
+		})
+	}
+}
+
+func Test_unrecordedPossessions(t *testing.T) {
+	t.Parallel()
+
+	recorded := []Possession{
+		{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+	}
+
+	cases := map[string]struct {
+		Possessions []NewPossession
+		Result      []NewPossession
+		Error       string
+	}{
+		"retried": {
+			Possessions: []NewPossession{
+				{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+				{Sequence: 2, ActionID: "zone", OutcomeID: "o2", Mode: ModeAttack},
+			},
+			Result: []NewPossession{
+				{Sequence: 2, ActionID: "zone", OutcomeID: "o2", Mode: ModeAttack},
+			},
+		},
+		"conflicting": {
+			Possessions: []NewPossession{
+				{Sequence: 1, ActionID: "w5", OutcomeID: "x3", Mode: ModeAttack},
+			},
+			Error: "possession 0: sequence 1 already recorded with different data",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pp, err := unrecordedPossessions(tc.Possessions, recorded)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Result, pp)
+		})
+	}
+}
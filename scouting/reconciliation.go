@@ -0,0 +1,72 @@
+package scouting
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+)
+
+// MatchReconciliation compares the final score of a match with the points
+// implied by scouted outcomes. Scouted points are only set for sides that
+// were covered by at least one finished scout.
+type MatchReconciliation struct {
+	MatchUUID            uuid.UUID        `db:"match_reconciliation.match_uuid"`
+	ScoutedPointsFor     null.Value[uint] `db:"match_reconciliation.scouted_points_for"`
+	ScoutedPointsAgainst null.Value[uint] `db:"match_reconciliation.scouted_points_against"`
+	PointsFor            uint             `db:"match_reconciliation.points_for"`
+	PointsAgainst        uint             `db:"match_reconciliation.points_against"`
+	Discrepancy          uint             `db:"match_reconciliation.discrepancy"`
+	Overridden           bool             `db:"match_reconciliation.overridden"`
+	CreatedAt            time.Time        `db:"match_reconciliation.created_at"`
+}
+
+type MatchReconciliationFilter struct {
+	MatchUUIDs []uuid.UUID
+}
+
+func reconcileMatch(m Match, cs configSet, mss []MatchScout, pp []Possession) MatchReconciliation {
+	mr := MatchReconciliation{
+		MatchUUID:     m.UUID,
+		PointsFor:     m.PointsFor(),
+		PointsAgainst: m.PointsAgainst(),
+	}
+
+	covered := make(map[Mode]bool)
+
+	for _, ms := range mss {
+		if !ms.FinishedAt.Valid {
+			continue
+		}
+
+		switch ms.Mode {
+		case ModeAttackDefence:
+			covered[ModeAttack] = true
+			covered[ModeDefence] = true
+		default:
+			covered[ms.Mode] = true
+		}
+	}
+
+	bs := computeBoxScore(cs, mss, pp)
+
+	if covered[ModeAttack] {
+		mr.ScoutedPointsFor = null.NewValue(bs.Attack.Points, true)
+		mr.Discrepancy += absDiff(bs.Attack.Points, mr.PointsFor)
+	}
+
+	if covered[ModeDefence] {
+		mr.ScoutedPointsAgainst = null.NewValue(bs.Defence.Points, true)
+		mr.Discrepancy += absDiff(bs.Defence.Points, mr.PointsAgainst)
+	}
+
+	return mr
+}
+
+func absDiff(a, b uint) uint {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
@@ -0,0 +1,82 @@
+package scouting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reconcileMatch(t *testing.T) {
+	t.Parallel()
+
+	home := uuid.Must(uuid.NewV7())
+	away := uuid.Must(uuid.NewV7())
+	finished := null.NewValue(time.Now(), true)
+
+	m := Match{
+		UUID:            uuid.Must(uuid.NewV7()),
+		HomeTeamUUID:    home,
+		AwayTeamUUID:    away,
+		ScoutedTeamUUID: away,
+		HomeScore:       null.NewValue(uint(7), true),
+		AwayScore:       null.NewValue(uint(5), true),
+	}
+
+	possession := func(aid string, mode Mode, outcome string) Possession {
+		return Possession{MatchUUID: m.UUID, AccountID: aid, Mode: mode, OutcomeID: outcome}
+	}
+
+	t.Run("attack covered only", func(t *testing.T) {
+		mss := []MatchScout{
+			{MatchUUID: m.UUID, AccountID: "a", Mode: ModeAttack, Submode: SubmodeAnyRules, FinishedAt: finished},
+		}
+
+		pp := []Possession{
+			possession("a", ModeAttack, "o2"),
+			possession("a", ModeAttack, "x3"),
+		}
+
+		mr := reconcileMatch(m, singleConfigSet(DefaultScoutingConfig, mss), mss, pp)
+
+		assert.Equal(t, null.NewValue(uint(2), true), mr.ScoutedPointsFor)
+		assert.False(t, mr.ScoutedPointsAgainst.Valid)
+		assert.Equal(t, uint(5), mr.PointsFor)
+		assert.Equal(t, uint(7), mr.PointsAgainst)
+		assert.Equal(t, uint(3), mr.Discrepancy)
+	})
+
+	t.Run("both sides covered", func(t *testing.T) {
+		mss := []MatchScout{
+			{MatchUUID: m.UUID, AccountID: "a", Mode: ModeAttackDefence, Submode: SubmodeAnyRules, FinishedAt: finished},
+		}
+
+		pp := []Possession{
+			possession("a", ModeAttack, "o2"),
+			possession("a", ModeAttack, "o3"),
+			possession("a", ModeDefence, "o3"),
+			possession("a", ModeDefence, "o2"),
+			possession("a", ModeDefence, "o2"),
+		}
+
+		mr := reconcileMatch(m, singleConfigSet(DefaultScoutingConfig, mss), mss, pp)
+
+		assert.Equal(t, null.NewValue(uint(5), true), mr.ScoutedPointsFor)
+		assert.Equal(t, null.NewValue(uint(7), true), mr.ScoutedPointsAgainst)
+		assert.Equal(t, uint(0), mr.Discrepancy)
+	})
+
+	t.Run("nothing covered", func(t *testing.T) {
+		mss := []MatchScout{
+			{MatchUUID: m.UUID, AccountID: "a", Mode: ModeAttack, Submode: SubmodeAnyRules},
+		}
+
+		mr := reconcileMatch(m, singleConfigSet(DefaultScoutingConfig, mss), mss, nil)
+
+		assert.False(t, mr.ScoutedPointsFor.Valid)
+		assert.False(t, mr.ScoutedPointsAgainst.Valid)
+		assert.Equal(t, uint(0), mr.Discrepancy)
+	})
+}
@@ -0,0 +1,299 @@
+package scouting
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+type MatchScoutAction string
+
+const (
+	MatchScoutActionReleased   MatchScoutAction = "released"
+	MatchScoutActionHandedOver MatchScoutAction = "handed_over"
+)
+
+// MatchScoutAudit records a released or handed over scouting slot.
+// Possessions is the number of possessions archived with a released slot or
+// moved with a handed over one.
+type MatchScoutAudit struct {
+	UUID            uuid.UUID          `db:"match_scout_audit.uuid"`
+	MatchUUID       uuid.UUID          `db:"match_scout_audit.match_uuid"`
+	Action          MatchScoutAction   `db:"match_scout_audit.action"`
+	AccountID       string             `db:"match_scout_audit.account_id"`
+	TargetAccountID null.Value[string] `db:"match_scout_audit.target_account_id"`
+	Mode            Mode               `db:"match_scout_audit.mode"`
+	Submode         Submode            `db:"match_scout_audit.submode"`
+	Possessions     int64              `db:"match_scout_audit.possessions"`
+	PerformedBy     string             `db:"match_scout_audit.performed_by"`
+	CreatedAt       time.Time          `db:"match_scout_audit.created_at"`
+}
+
+func newMatchScoutAudit(ms MatchScout, action MatchScoutAction, performedBy string) MatchScoutAudit {
+	return MatchScoutAudit{
+		UUID:        uuid.Must(uuid.NewV7()),
+		MatchUUID:   ms.MatchUUID,
+		Action:      action,
+		AccountID:   ms.AccountID,
+		Mode:        ms.Mode,
+		Submode:     ms.Submode,
+		PerformedBy: performedBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// selectUnfinishedMatchScout returns the pending or accepted slot of the
+// account in an active match.
+func selectUnfinishedMatchScout(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, matchUUID uuid.UUID, aid string) (MatchScout, error) {
+	mm, err := SelectMatches(ctx, tx, MatchFilter{
+		UUID:           matchUUID,
+		Active:         true,
+		OrganizationID: oid,
+	}, true)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	mss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+		MatchUUID: &matchUUID,
+		AccountID: &aid,
+		Statuses:  claimingMatchScoutStatuses,
+	})
+	switch {
+	case err == nil && len(mss) > 0:
+		// OK.
+	case err == nil && len(mss) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("match scout")
+	default:
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	if mss[0].FinishedAt.Valid {
+		return MatchScout{}, sbd.NewValidationError("match scout already finished")
+	}
+
+	return mss[0], nil
+}
+
+// ReleaseMatchScout frees the unfinished slot of the account so its mode and
+// submode can be claimed again. Possessions recorded for the slot are
+// archived with the release and no longer count towards the match,
+// HandOverMatchScout keeps them on the slot.
+func ReleaseMatchScout(ctx context.Context, sdb *sqlx.DB, oid, performedBy string, matchUUID uuid.UUID, aid string) error {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("performed_by", performedBy),
+		slog.String("match_uuid", matchUUID.String()),
+		slog.String("account_id", aid),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	defer tx.Rollback()
+
+	ms, err := selectUnfinishedMatchScout(ctx, tx, logger, oid, matchUUID, aid)
+	if err != nil {
+		return err
+	}
+
+	if err = releaseMatchScout(ctx, tx, logger, ms, performedBy); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	return nil
+}
+
+// releaseMatchScout archives the possessions of the slot under its audit
+// and removes the slot.
+func releaseMatchScout(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, ms MatchScout, performedBy string) error {
+	var err error
+
+	ma := newMatchScoutAudit(ms, MatchScoutActionReleased, performedBy)
+
+	ma.Possessions, err = archivePossessions(ctx, tx, ma.UUID, ms.MatchUUID, ms.AccountID)
+	if err != nil {
+		logger.Error("archiving possessions", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	if err = deleteMatchScout(ctx, tx, ms.MatchUUID, ms.AccountID); err != nil {
+		logger.Error("deleting match scout", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	if err = insertMatchScoutAudit(ctx, tx, ma); err != nil {
+		logger.Error("inserting match scout audit", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	return nil
+}
+
+// HandOverMatchScout transfers the unfinished slot of the account, including
+// possessions already recorded, to another organization account that does
+// not scout the match yet.
+func HandOverMatchScout(ctx context.Context, sdb *sqlx.DB, oid, performedBy string, matchUUID uuid.UUID, aid, targetID string) (MatchScout, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("performed_by", performedBy),
+		slog.String("match_uuid", matchUUID.String()),
+		slog.String("account_id", aid),
+		slog.String("target_account_id", targetID),
+	)
+
+	if aid == targetID {
+		return MatchScout{}, sbd.NewValidationError("cannot hand over to the same account")
+	}
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	ms, err := selectUnfinishedMatchScout(ctx, tx, logger, oid, matchUUID, aid)
+	if err != nil {
+		return MatchScout{}, err
+	}
+
+	aa, err := SelectAccounts(ctx, tx, AccountFilter{
+		ID:             targetID,
+		OrganizationID: oid,
+	})
+	switch {
+	case err == nil && len(aa) > 0:
+		// OK.
+	case err == nil && len(aa) == 0:
+		return MatchScout{}, sbd.NewNotFoundError("account")
+	default:
+		logger.Error("selecting accounts", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	tss, err := SelectMatchScouts(ctx, tx, MatchScoutFilter{
+		MatchUUID: &matchUUID,
+		AccountID: &targetID,
+		Statuses:  []MatchScoutStatus{MatchScoutStatusPending, MatchScoutStatusAccepted, MatchScoutStatusDeclined},
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	for _, ts := range tss {
+		if ts.Status != MatchScoutStatusDeclined {
+			return MatchScout{}, sbd.NewValidationError("account already scouting this match")
+		}
+
+		// A declined assignment of the target has no possessions and
+		// would block the transfer.
+		if err = deleteMatchScout(ctx, tx, matchUUID, targetID); err != nil {
+			logger.Error("deleting match scout", slog.Any("error", err))
+
+			return MatchScout{}, errInternal
+		}
+	}
+
+	pp, err := SelectPossessions(ctx, tx, PossessionFilter{
+		MatchUUID: &matchUUID,
+		AccountID: &aid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	ma := newMatchScoutAudit(ms, MatchScoutActionHandedOver, performedBy)
+	ma.TargetAccountID = null.NewValue(targetID, true)
+	ma.Possessions = int64(len(pp))
+
+	if err = transferMatchScout(ctx, tx, matchUUID, aid, targetID); err != nil {
+		logger.Error("transferring match scout", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	if err = insertMatchScoutAudit(ctx, tx, ma); err != nil {
+		logger.Error("inserting match scout audit", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return MatchScout{}, errInternal
+	}
+
+	ms.AccountID = targetID
+
+	return ms, nil
+}
+
+// SelectMatchScoutAudits returns released and handed over slots of the
+// match, oldest first.
+func SelectMatchScoutAudits(ctx context.Context, sdb *sqlx.DB, oid string, matchUUID uuid.UUID) ([]MatchScoutAudit, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		UUID:           matchUUID,
+		AnyState:       true,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return nil, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	maa, err := selectMatchScoutAudits(ctx, sdb, matchUUID)
+	if err != nil {
+		logger.Error("selecting match scout audits", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return maa, nil
+}
@@ -0,0 +1,77 @@
+package scouting
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+func (s *Suite) Test_ReleaseAndHandOverMatchScout() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	b := s.createAccount("o1", "2")
+	c := s.createAccount("o1", "3")
+	m := s.createMatch("o1")
+
+	err = ScoutMatch(context.Background(), s.sdb, "o1", a.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	_, err = AppendPossessions(context.Background(), s.sdb, "o1", a.ID, m.UUID, []NewPossession{
+		{Sequence: 1, ActionID: "w5", OutcomeID: "o2", Mode: ModeAttack},
+		{Sequence: 2, ActionID: "zone", OutcomeID: "x3", Mode: ModeAttack},
+	})
+	s.Require().NoError(err)
+
+	_, err = HandOverMatchScout(context.Background(), s.sdb, "o1", a.ID, m.UUID, a.ID, "unknown")
+	s.Assert().Equal(sbd.NewNotFoundError("account"), err)
+
+	ms, err := HandOverMatchScout(context.Background(), s.sdb, "o1", a.ID, m.UUID, a.ID, b.ID)
+	s.Require().NoError(err)
+	s.Assert().Equal(b.ID, ms.AccountID)
+
+	s.Assert().Equal(2, s.selectCount("possession", squirrel.Eq{"account_id": b.ID}))
+	s.Assert().Equal(0, s.selectCount("match_scout", squirrel.Eq{"account_id": a.ID}))
+
+	err = ReleaseMatchScout(context.Background(), s.sdb, "o1", a.ID, m.UUID, a.ID)
+	s.Assert().Equal(sbd.NewNotFoundError("match scout"), err)
+
+	err = ReleaseMatchScout(context.Background(), s.sdb, "o1", "coordinator", m.UUID, b.ID)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(0, s.selectCount("possession", squirrel.Eq{"match_uuid": m.UUID}))
+	s.Assert().Equal(2, s.selectCount("released_possession", squirrel.Eq{"account_id": b.ID}))
+
+	// The released mode and submode can be claimed again.
+	err = ScoutMatch(context.Background(), s.sdb, "o1", c.ID, m.UUID, NewMatchScout{
+		Mode:    ModeAttack,
+		Submode: SubmodeAllRules,
+	})
+	s.Require().NoError(err)
+
+	_, err = SubmitScoutReport(context.Background(), s.sdb, "o1", c.ID, m.UUID, ScoutReport{})
+	s.Require().NoError(err)
+
+	err = ReleaseMatchScout(context.Background(), s.sdb, "o1", c.ID, m.UUID, c.ID)
+	s.Assert().Equal(sbd.NewValidationError("match scout already finished"), err)
+
+	maa, err := SelectMatchScoutAudits(context.Background(), s.sdb, "o1", m.UUID)
+	s.Require().NoError(err)
+	s.Require().Len(maa, 2)
+
+	s.Assert().Equal(MatchScoutActionHandedOver, maa[0].Action)
+	s.Assert().Equal(a.ID, maa[0].AccountID)
+	s.Assert().Equal(b.ID, maa[0].TargetAccountID.V)
+	s.Assert().Equal(int64(2), maa[0].Possessions)
+
+	s.Assert().Equal(MatchScoutActionReleased, maa[1].Action)
+	s.Assert().Equal(b.ID, maa[1].AccountID)
+	s.Assert().Equal("coordinator", maa[1].PerformedBy)
+	s.Assert().Equal(int64(2), maa[1].Possessions)
+	s.Assert().Equal(2, s.selectCount("released_possession", squirrel.Eq{"match_scout_audit_uuid": maa[1].UUID}))
+}
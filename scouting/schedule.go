@@ -0,0 +1,272 @@
+package scouting
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// Fixture is a row of an imported schedule. Line is the CSV line number.
+type Fixture struct {
+	Line     int
+	StartsAt time.Time
+	HomeTeam string
+	AwayTeam string
+}
+
+// FixtureError describes why a schedule row cannot be imported.
+type FixtureError struct {
+	Line    int
+	Message string
+}
+
+// ScheduleImport previews or reports the result of a schedule import.
+// Matches are only stored when Applied is set.
+type ScheduleImport struct {
+	Matches []Match
+	Errors  []FixtureError
+	Applied bool
+}
+
+// parseFixtures reads CSV rows of start time (RFC 3339), home team name and
+// away team name. A leading header row starting with "starts_at" is skipped.
+// Rows that cannot be parsed are reported as errors.
+func parseFixtures(r io.Reader) ([]Fixture, []FixtureError, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var (
+		ff []Fixture
+		fe []FixtureError
+	)
+
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var pe *csv.ParseError
+
+		switch {
+		case err == nil:
+			// OK.
+		case errors.As(err, &pe):
+			return nil, nil, sbd.NewValidationError(fmt.Sprintf("invalid csv: %v", pe))
+		default:
+			return nil, nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if len(ff) == 0 && len(fe) == 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "starts_at") {
+			continue
+		}
+
+		if len(rec) != 3 {
+			fe = append(fe, FixtureError{Line: line, Message: fmt.Sprintf("expected 3 columns, found %d", len(rec))})
+
+			continue
+		}
+
+		startsAt, err := time.Parse(time.RFC3339, strings.TrimSpace(rec[0]))
+		if err != nil {
+			fe = append(fe, FixtureError{Line: line, Message: "starts at must be an RFC 3339 date-time"})
+
+			continue
+		}
+
+		ff = append(ff, Fixture{
+			Line:     line,
+			StartsAt: startsAt,
+			HomeTeam: strings.TrimSpace(rec[1]),
+			AwayTeam: strings.TrimSpace(rec[2]),
+		})
+	}
+
+	return ff, fe, nil
+}
+
+// seasonRoster resolves team names of a season, ignoring case.
+type seasonRoster map[string][]Team
+
+func newSeasonRoster(tt []Team) seasonRoster {
+	sr := make(seasonRoster, len(tt))
+
+	for _, t := range tt {
+		key := strings.ToLower(t.Name)
+		sr[key] = append(sr[key], t)
+	}
+
+	return sr
+}
+
+func (sr seasonRoster) team(name string) (Team, error) {
+	tt := sr[strings.ToLower(name)]
+
+	switch len(tt) {
+	case 0:
+		return Team{}, fmt.Errorf("team %q not found in season", name)
+	case 1:
+		return tt[0], nil
+	default:
+		return Team{}, fmt.Errorf("team name %q is ambiguous", name)
+	}
+}
+
+// ImportSchedule creates matches of the league from CSV fixtures. Each
+// fixture goes through the same checks as CreateMatch and is placed in the
+// given season or, when seasonUUID is not set, in the season it starts in.
+// Matches are only stored when no fixture has errors and it is not a dry run.
+func ImportSchedule(ctx context.Context, sdb *sqlx.DB, oid, aid string, leagueUUID, seasonUUID uuid.UUID, r io.Reader, dryRun bool) (ScheduleImport, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+		slog.String("league_uuid", leagueUUID.String()),
+	)
+
+	ff, fe, err := parseFixtures(r)
+	if err != nil {
+		return ScheduleImport{}, err
+	}
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return ScheduleImport{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	ss, err := SelectSeasons(ctx, tx, SeasonFilter{
+		UUID:           seasonUUID,
+		LeagueUUID:     leagueUUID,
+		OrganizationID: oid,
+	})
+	switch {
+	case err == nil && len(ss) > 0:
+		// OK.
+	case err == nil && len(ss) == 0:
+		return ScheduleImport{}, sbd.NewNotFoundError("season")
+	default:
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return ScheduleImport{}, errInternal
+	}
+
+	rosters := make(map[uuid.UUID]seasonRoster, len(ss))
+
+	roster := func(s Season) (seasonRoster, error) {
+		if sr, ok := rosters[s.UUID]; ok {
+			return sr, nil
+		}
+
+		tt, err := SelectTeams(ctx, tx, TeamFilter{
+			SeasonUUID:     s.UUID,
+			OrganizationID: oid,
+		})
+		if err != nil {
+			logger.Error("selecting teams", slog.Any("error", err))
+
+			return nil, errInternal
+		}
+
+		rosters[s.UUID] = newSeasonRoster(tt)
+
+		return rosters[s.UUID], nil
+	}
+
+	si := ScheduleImport{
+		Errors: fe,
+	}
+
+	fail := func(f Fixture, msg string) {
+		si.Errors = append(si.Errors, FixtureError{Line: f.Line, Message: msg})
+	}
+
+	for _, f := range ff {
+		season := ss[0]
+
+		if seasonUUID.IsNil() {
+			var ok bool
+
+			season, ok = seasonAt(ss, f.StartsAt)
+			if !ok {
+				fail(f, "no season at starts at")
+
+				continue
+			}
+		}
+
+		sr, err := roster(season)
+		if err != nil {
+			return ScheduleImport{}, err
+		}
+
+		home, err := sr.team(f.HomeTeam)
+		if err != nil {
+			fail(f, err.Error())
+
+			continue
+		}
+
+		away, err := sr.team(f.AwayTeam)
+		if err != nil {
+			fail(f, err.Error())
+
+			continue
+		}
+
+		m, err := createMatch(ctx, tx, logger, oid, aid, NewMatch{
+			LeagueUUID:   leagueUUID,
+			SeasonUUID:   season.UUID,
+			HomeTeamUUID: home.UUID,
+			AwayTeamUUID: away.UUID,
+			StartsAt:     f.StartsAt,
+		})
+
+		var (
+			ve  *sbd.ValidationError
+			nfe *sbd.NotFoundError
+		)
+
+		switch {
+		case err == nil:
+			si.Matches = append(si.Matches, m)
+		case errors.As(err, &ve), errors.As(err, &nfe):
+			fail(f, err.Error())
+		default:
+			return ScheduleImport{}, err
+		}
+	}
+
+	if dryRun || len(si.Errors) > 0 {
+		slices.SortStableFunc(si.Errors, func(a, b FixtureError) int {
+			return a.Line - b.Line
+		})
+
+		return si, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return ScheduleImport{}, errInternal
+	}
+
+	si.Applied = true
+
+	return si, nil
+}
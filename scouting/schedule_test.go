@@ -0,0 +1,106 @@
+package scouting
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseFixtures(t *testing.T) {
+	t.Parallel()
+
+	data := `starts_at,home,away
+2024-10-01T18:00:00Z, Lions ,Tigers
+2024-10-02T18:00:00Z,Lions
+tomorrow,Lions,Tigers
+`
+
+	ff, fe, err := parseFixtures(strings.NewReader(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Fixture{
+		{
+			Line:     2,
+			StartsAt: time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC),
+			HomeTeam: "Lions",
+			AwayTeam: "Tigers",
+		},
+	}, ff)
+
+	assert.Equal(t, []FixtureError{
+		{Line: 3, Message: "expected 3 columns, found 2"},
+		{Line: 4, Message: "starts at must be an RFC 3339 date-time"},
+	}, fe)
+
+	_, _, err = parseFixtures(strings.NewReader(`"unterminated`))
+	assert.Error(t, err)
+}
+
+func (s *Suite) Test_ImportSchedule() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	lions, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "Lions",
+	})
+	s.Require().NoError(err)
+
+	tigers, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "Tigers",
+	})
+	s.Require().NoError(err)
+
+	l, _, err := CreateLeague(context.Background(), s.sdb, "o1", NewLeague{
+		Name:   "league",
+		Season: testSeason(lions.UUID, tigers.UUID),
+	})
+	s.Require().NoError(err)
+
+	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	starts := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	valid := starts.Format(time.RFC3339) + ",lions,Tigers\n" +
+		starts.Add(time.Hour).Format(time.RFC3339) + ",Tigers,Lions\n"
+
+	s.Run("dry run", func() {
+		si, err := ImportSchedule(context.Background(), s.sdb, "o1", "test_scout", l.UUID, uuid.Nil, strings.NewReader(valid), true)
+		s.Require().NoError(err)
+
+		s.Assert().False(si.Applied)
+		s.Assert().Empty(si.Errors)
+		s.Require().Len(si.Matches, 2)
+		s.Assert().Equal(lions.UUID, si.Matches[0].HomeTeamUUID)
+
+		s.Assert().Equal(0, s.selectCount("match", squirrel.Eq{"organization_id": "o1"}))
+	})
+
+	s.Run("row errors roll back everything", func() {
+		data := valid +
+			starts.Format(time.RFC3339) + ",Lions,Bears\n" +
+			time.Now().Add(-30*time.Minute).Format(time.RFC3339) + ",Lions,Tigers\n"
+
+		si, err := ImportSchedule(context.Background(), s.sdb, "o1", "test_scout", l.UUID, uuid.Nil, strings.NewReader(data), false)
+		s.Require().NoError(err)
+
+		s.Assert().False(si.Applied)
+		s.Assert().Equal([]FixtureError{
+			{Line: 3, Message: `team "Bears" not found in season`},
+			{Line: 4, Message: "starts at cannot be before now"},
+		}, si.Errors)
+
+		s.Assert().Equal(0, s.selectCount("match", squirrel.Eq{"organization_id": "o1"}))
+	})
+
+	si, err := ImportSchedule(context.Background(), s.sdb, "o1", "test_scout", l.UUID, uuid.Nil, strings.NewReader(valid), false)
+	s.Require().NoError(err)
+
+	s.Assert().True(si.Applied)
+	s.Assert().Equal(2, s.selectCount("match", squirrel.Eq{"organization_id": "o1"}))
+}
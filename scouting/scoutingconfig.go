@@ -1,8 +1,12 @@
 package scouting
 
 import (
+	"database/sql/driver"
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -18,7 +22,203 @@ type ScoutingConfig struct {
 
 type Layout struct {
 	Name    string   `yaml:"name" json:"name"`
-	Actions []string `yaml:"actions" json:"actions"`
+	Actions []string `yaml:"actions,omitempty" json:"actions"`
+}
+
+// LayoutActions are the actions of the layout a match scout picked, copied
+// when the match is claimed so later layout edits don't affect the scout.
+type LayoutActions []string
+
+func (la LayoutActions) Value() (driver.Value, error) {
+	if la == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(la)
+}
+
+func (la *LayoutActions) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*la = nil
+
+		return nil
+	case []byte:
+		return json.Unmarshal(v, la)
+	case string:
+		return json.Unmarshal([]byte(v), la)
+	default:
+		return errors.New("unsupported layout actions source type")
+	}
+}
+
+// ConfigProblem is a structural problem of a scouting config. Path points
+// to the offending field, e.g. "layouts[1].actions[3]".
+type ConfigProblem struct {
+	Path    string
+	Message string
+}
+
+func (cp ConfigProblem) String() string {
+	return cp.Path + ": " + cp.Message
+}
+
+// Problems reports every structural problem of the config: missing or
+// duplicate identifiers, layouts referencing unknown actions and outcomes
+// that are inconsistent.
+func (sc *ScoutingConfig) Problems() []ConfigProblem {
+	var pp []ConfigProblem
+
+	add := func(msg string, path string, args ...any) {
+		pp = append(pp, ConfigProblem{
+			Path:    fmt.Sprintf(path, args...),
+			Message: msg,
+		})
+	}
+
+	actions := make(map[string]int, len(sc.Actions))
+
+	for i, a := range sc.Actions {
+		switch j, ok := actions[a.ID]; {
+		case a.ID == "":
+			add("action id is required", "actions[%d].id", i)
+		case ok:
+			add(fmt.Sprintf("duplicate action %q, first defined at actions[%d]", a.ID, j), "actions[%d].id", i)
+		default:
+			actions[a.ID] = i
+		}
+
+		options := make(map[string]int, len(a.Options))
+
+		for k, o := range a.Options {
+			switch j, ok := options[o.ID]; {
+			case o.ID == "":
+				add("option id is required", "actions[%d].options[%d].id", i, k)
+			case ok:
+				add(fmt.Sprintf("duplicate option %q, first defined at actions[%d].options[%d]", o.ID, i, j), "actions[%d].options[%d].id", i, k)
+			default:
+				options[o.ID] = k
+			}
+		}
+	}
+
+	outcomes := make(map[string]int, len(sc.Outcomes))
+
+	for i, o := range sc.Outcomes {
+		switch j, ok := outcomes[o.ID]; {
+		case o.ID == "":
+			add("outcome id is required", "outcomes[%d].id", i)
+		case ok:
+			add(fmt.Sprintf("duplicate outcome %q, first defined at outcomes[%d]", o.ID, j), "outcomes[%d].id", i)
+		default:
+			outcomes[o.ID] = i
+		}
+
+		if o.PossibleFreeThrows > 0 && !o.EndedInShot {
+			add("free throws without a shot", "outcomes[%d].possible_free_throws", i)
+		}
+	}
+
+	layouts := make(map[string]int, len(sc.Layouts))
+
+	for i, l := range sc.Layouts {
+		switch j, ok := layouts[l.Name]; {
+		case l.Name == "":
+			add("layout name is required", "layouts[%d].name", i)
+		case ok:
+			add(fmt.Sprintf("duplicate layout %q, first defined at layouts[%d]", l.Name, j), "layouts[%d].name", i)
+		default:
+			layouts[l.Name] = i
+		}
+
+		seen := make(map[string]struct{}, len(l.Actions))
+
+		for k, id := range l.Actions {
+			if _, ok := actions[id]; !ok {
+				add(fmt.Sprintf("unknown action %q", id), "layouts[%d].actions[%d]", i, k)
+			}
+
+			if _, ok := seen[id]; ok {
+				add(fmt.Sprintf("duplicate action %q", id), "layouts[%d].actions[%d]", i, k)
+			}
+
+			seen[id] = struct{}{}
+		}
+	}
+
+	return pp
+}
+
+// Validate returns an error listing all problems of the config.
+func (sc *ScoutingConfig) Validate() error {
+	pp := sc.Problems()
+	if len(pp) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(pp))
+
+	for i, p := range pp {
+		msgs[i] = p.String()
+	}
+
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+func (sc ScoutingConfig) Value() (driver.Value, error) {
+	return json.Marshal(sc)
+}
+
+func (sc *ScoutingConfig) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, sc)
+	case string:
+		return json.Unmarshal([]byte(v), sc)
+	default:
+		return errors.New("unsupported scouting config source type")
+	}
+}
+
+func (sc *ScoutingConfig) action(id string) (Action, bool) {
+	for _, a := range sc.Actions {
+		if a.ID == id {
+			return a, true
+		}
+	}
+
+	return Action{}, false
+}
+
+func (sc *ScoutingConfig) layout(name string) (Layout, bool) {
+	for _, l := range sc.Layouts {
+		if l.Name == name {
+			return l, true
+		}
+	}
+
+	return Layout{}, false
+}
+
+// layoutActions returns a copy of the named layout's actions, or nil when no
+// layout is picked.
+func (sc *ScoutingConfig) layoutActions(name string) LayoutActions {
+	l, ok := sc.layout(name)
+	if !ok {
+		return nil
+	}
+
+	return append(LayoutActions{}, l.Actions...)
+}
+
+func (sc *ScoutingConfig) outcome(id string) (Outcome, bool) {
+	for _, o := range sc.Outcomes {
+		if o.ID == id {
+			return o, true
+		}
+	}
+
+	return Outcome{}, false
 }
 
 var DefaultScoutingConfig ScoutingConfig
@@ -32,11 +232,25 @@ func init() {
 	if err = yaml.Unmarshal(data, &DefaultScoutingConfig); err != nil {
 		panic(fmt.Sprintf("parsing default scout config: %v", err))
 	}
+
+	if err = DefaultScoutingConfig.Validate(); err != nil {
+		panic(fmt.Sprintf("validating default scout config: %v", err))
+	}
 }
 
 type Action struct {
 	ID      string         `yaml:"id" json:"id"`
-	Options []ActionOption `yaml:"options" json:"options"`
+	Options []ActionOption `yaml:"options,omitempty" json:"options"`
+}
+
+func (a *Action) hasOption(id string) bool {
+	for _, o := range a.Options {
+		if o.ID == id {
+			return true
+		}
+	}
+
+	return false
 }
 
 type ActionOption struct {
@@ -45,8 +259,9 @@ type ActionOption struct {
 
 type Outcome struct {
 	ID                 string   `yaml:"id" json:"id"`
-	Points             uint     `yaml:"points" json:"points"`
-	EndedInShot        bool     `yaml:"ended_in_shot" json:"ended_in_shot"`
-	PossibleFreeThrows uint     `yaml:"possible_free_throws" json:"possible_free_throws"`
-	StatisticTags      []string `yaml:"statistic_tags" json:"statistic_tags"`
+	Points             uint     `yaml:"points,omitempty" json:"points"`
+	EndedInShot        bool     `yaml:"ended_in_shot,omitempty" json:"ended_in_shot"`
+	PossibleFreeThrows uint     `yaml:"possible_free_throws,omitempty" json:"possible_free_throws"`
+	Turnover           bool     `yaml:"turnover,omitempty" json:"turnover"`
+	StatisticTags      []string `yaml:"statistic_tags,omitempty" json:"statistic_tags"`
 }
@@ -0,0 +1,120 @@
+package scouting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScoutingConfig_Problems(t *testing.T) {
+	t.Parallel()
+
+	valid := func() ScoutingConfig {
+		return ScoutingConfig{
+			Actions: []Action{
+				{ID: "w5", Options: []ActionOption{{ID: "1"}, {ID: "2"}}},
+				{ID: "w4"},
+			},
+			Outcomes: []Outcome{
+				{ID: "2p", Points: 2, EndedInShot: true},
+				{ID: "to", Turnover: true},
+			},
+			Layouts: []Layout{
+				{Name: "Basic", Actions: []string{"w5", "w4"}},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		Modify   func(cfg *ScoutingConfig)
+		Problems []ConfigProblem
+	}{
+		"valid": {
+			Modify: func(_ *ScoutingConfig) {},
+		},
+		"empty action id": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Actions = append(cfg.Actions, Action{})
+			},
+			Problems: []ConfigProblem{
+				{Path: "actions[2].id", Message: "action id is required"},
+			},
+		},
+		"duplicate action": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Actions = append(cfg.Actions, Action{ID: "w5"})
+			},
+			Problems: []ConfigProblem{
+				{Path: "actions[2].id", Message: `duplicate action "w5", first defined at actions[0]`},
+			},
+		},
+		"duplicate option": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Actions[1].Options = []ActionOption{{ID: "1"}, {ID: "1"}, {}}
+			},
+			Problems: []ConfigProblem{
+				{Path: "actions[1].options[1].id", Message: `duplicate option "1", first defined at actions[1].options[0]`},
+				{Path: "actions[1].options[2].id", Message: "option id is required"},
+			},
+		},
+		"duplicate outcome": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Outcomes = append(cfg.Outcomes, Outcome{ID: "to"})
+			},
+			Problems: []ConfigProblem{
+				{Path: "outcomes[2].id", Message: `duplicate outcome "to", first defined at outcomes[1]`},
+			},
+		},
+		"free throws without shot": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Outcomes[1].PossibleFreeThrows = 2
+			},
+			Problems: []ConfigProblem{
+				{Path: "outcomes[1].possible_free_throws", Message: "free throws without a shot"},
+			},
+		},
+		"duplicate layout": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Layouts = append(cfg.Layouts, Layout{Name: "Basic"}, Layout{})
+			},
+			Problems: []ConfigProblem{
+				{Path: "layouts[1].name", Message: `duplicate layout "Basic", first defined at layouts[0]`},
+				{Path: "layouts[2].name", Message: "layout name is required"},
+			},
+		},
+		"dangling layout references": {
+			Modify: func(cfg *ScoutingConfig) {
+				cfg.Layouts[0].Actions = append(cfg.Layouts[0].Actions, "tw5", "w4", "sw5")
+			},
+			Problems: []ConfigProblem{
+				{Path: "layouts[0].actions[2]", Message: `unknown action "tw5"`},
+				{Path: "layouts[0].actions[3]", Message: `duplicate action "w4"`},
+				{Path: "layouts[0].actions[4]", Message: `unknown action "sw5"`},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := valid()
+			tc.Modify(&cfg)
+
+			assert.Equal(t, tc.Problems, cfg.Problems())
+		})
+	}
+}
+
+func Test_ScoutingConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultScoutingConfig.Validate())
+
+	cfg := ScoutingConfig{
+		Actions: []Action{{ID: "w5"}, {ID: "w5"}},
+		Layouts: []Layout{{Name: "Basic", Actions: []string{"tw5"}}},
+	}
+
+	assert.EqualError(t, cfg.Validate(), `actions[1].id: duplicate action "w5", first defined at actions[0]; layouts[0].actions[0]: unknown action "tw5"`)
+}
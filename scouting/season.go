@@ -0,0 +1,468 @@
+package scouting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// Season is a period of a league with its own team membership. Seasons of a
+// league never overlap, StartsAt is inclusive and EndsAt exclusive.
+type Season struct {
+	UUID       uuid.UUID `db:"season.uuid"`
+	LeagueUUID uuid.UUID `db:"season.league_uuid"`
+	Name       string    `db:"season.name"`
+	StartsAt   time.Time `db:"season.starts_at"`
+	EndsAt     time.Time `db:"season.ends_at"`
+	// StandingsTieBreakers order teams with the same win percentage in the
+	// standings. DefaultTieBreakers are used when empty.
+	StandingsTieBreakers TieBreakers `db:"season.standings_tie_breakers"`
+
+	CreatedAt  time.Time `db:"season.created_at"`
+	ModifiedAt time.Time `db:"season.modified_at"`
+}
+
+type NewSeason struct {
+	Name                 string      `json:"name"`
+	StartsAt             time.Time   `json:"starts_at"`
+	EndsAt               time.Time   `json:"ends_at"`
+	TeamUUIDs            []uuid.UUID `json:"team_uuids"`
+	StandingsTieBreakers TieBreakers `json:"standings_tie_breakers"`
+}
+
+// SeasonFilter returns seasons of leagues linked to OrganizationID, when set.
+type SeasonFilter struct {
+	UUID           uuid.UUID
+	LeagueUUID     uuid.UUID
+	OrganizationID string
+}
+
+func (ns *NewSeason) Validate() error {
+	if ns.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if ns.StartsAt.IsZero() || ns.EndsAt.IsZero() {
+		return errors.New("starts at and ends at are required")
+	}
+
+	if !ns.EndsAt.After(ns.StartsAt) {
+		return errors.New("ends at must be after starts at")
+	}
+
+	return ns.StandingsTieBreakers.Validate()
+}
+
+func (ns *NewSeason) ToSeason(leagueUUID uuid.UUID) Season {
+	tnow := time.Now()
+
+	return Season{
+		UUID:                 uuid.Must(uuid.NewV7()),
+		LeagueUUID:           leagueUUID,
+		Name:                 ns.Name,
+		StartsAt:             ns.StartsAt,
+		EndsAt:               ns.EndsAt,
+		CreatedAt:            tnow,
+		ModifiedAt:           tnow,
+		StandingsTieBreakers: ns.StandingsTieBreakers,
+	}
+}
+
+func (s *Season) tieBreakers() []TieBreaker {
+	if len(s.StandingsTieBreakers) == 0 {
+		return DefaultTieBreakers
+	}
+
+	return s.StandingsTieBreakers
+}
+
+func (s *Season) contains(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+func (s *Season) overlaps(o Season) bool {
+	return s.StartsAt.Before(o.EndsAt) && o.StartsAt.Before(s.EndsAt)
+}
+
+// seasonAt returns the season running at t.
+func seasonAt(ss []Season, t time.Time) (Season, bool) {
+	for _, s := range ss {
+		if s.contains(t) {
+			return s, true
+		}
+	}
+
+	return Season{}, false
+}
+
+// currentSeason returns the season running at t or, between seasons, the
+// latest season that already started.
+func currentSeason(ss []Season, t time.Time) (Season, bool) {
+	var (
+		latest Season
+		found  bool
+	)
+
+	if s, ok := seasonAt(ss, t); ok {
+		return s, true
+	}
+
+	for _, s := range ss {
+		if s.StartsAt.After(t) {
+			continue
+		}
+
+		if !found || s.StartsAt.After(latest.StartsAt) {
+			latest = s
+			found = true
+		}
+	}
+
+	return latest, found
+}
+
+// resolveSeason returns the season of the league with the given uuid, or the
+// current one when the uuid is not set.
+func resolveSeason(ctx context.Context, qr sqlx.QueryerContext, logger *slog.Logger, oid string, leagueUUID, seasonUUID uuid.UUID) (Season, error) {
+	ss, err := SelectSeasons(ctx, qr, SeasonFilter{
+		UUID:           seasonUUID,
+		LeagueUUID:     leagueUUID,
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	if !seasonUUID.IsNil() {
+		if len(ss) == 0 {
+			return Season{}, sbd.NewNotFoundError("season")
+		}
+
+		return ss[0], nil
+	}
+
+	s, ok := currentSeason(ss, time.Now())
+	if !ok {
+		return Season{}, sbd.NewNotFoundError("season")
+	}
+
+	return s, nil
+}
+
+// addSeason stores the season and its teams, which must be visible to the
+// organization. The season may not overlap with other seasons of the league,
+// callers lock the league so concurrent seasons are checked one after
+// another.
+func addSeason(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, leagueUUID uuid.UUID, ns NewSeason) (Season, error) {
+	if err := ns.Validate(); err != nil {
+		return Season{}, sbd.NewValidationError(err.Error())
+	}
+
+	s := ns.ToSeason(leagueUUID)
+
+	ss, err := SelectSeasons(ctx, tx, SeasonFilter{
+		LeagueUUID: leagueUUID,
+	})
+	if err != nil {
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	for _, o := range ss {
+		if s.overlaps(o) {
+			return Season{}, sbd.NewValidationError(fmt.Sprintf("season overlaps with season %q", o.Name))
+		}
+	}
+
+	if err = insertSeason(ctx, tx, s); err != nil {
+		logger.Error("inserting season", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	if err = addSeasonTeams(ctx, tx, logger, oid, s.UUID, ns.TeamUUIDs); err != nil {
+		return Season{}, err
+	}
+
+	return s, nil
+}
+
+// addSeasonTeams adds teams visible to the organization to a new season.
+func addSeasonTeams(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, seasonUUID uuid.UUID, tuuids []uuid.UUID) error {
+	tt, err := selectSeasonTeams(ctx, tx, logger, oid, tuuids)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tt {
+		if err := insertSeasonTeam(ctx, tx, seasonUUID, t.UUID); err != nil {
+			logger.Error("inserting season team", slog.Any("error", err))
+
+			return errInternal
+		}
+	}
+
+	return nil
+}
+
+// selectSeasonTeams returns the teams with the given uuids, all of which must
+// be visible to the organization and listed once.
+func selectSeasonTeams(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, tuuids []uuid.UUID) ([]Team, error) {
+	if len(tuuids) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(tuuids))
+
+	for _, tu := range tuuids {
+		if _, ok := seen[tu]; ok {
+			return nil, sbd.NewValidationError(fmt.Sprintf("duplicate team %s", tu))
+		}
+
+		seen[tu] = struct{}{}
+	}
+
+	tt, err := SelectTeams(ctx, tx, TeamFilter{
+		UUIDs:          tuuids,
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting teams", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	if len(tt) != len(tuuids) {
+		return nil, sbd.NewValidationError(fmt.Sprintf("expected %d teams, found %d", len(tuuids), len(tt)))
+	}
+
+	return tt, nil
+}
+
+// CreateSeason adds a season to a league linked to the organization.
+func CreateSeason(ctx context.Context, sdb *sqlx.DB, oid string, leagueUUID uuid.UUID, ns NewSeason) (Season, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("league_uuid", leagueUUID.String()),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	ll, err := SelectLeagues(ctx, tx, LeagueFilter{
+		LeagueUUID:     leagueUUID,
+		OrganizationID: oid,
+	}, true)
+	switch {
+	case err == nil && len(ll) > 0:
+		// OK.
+	case err == nil && len(ll) == 0:
+		return Season{}, sbd.NewNotFoundError("league")
+	default:
+		logger.Error("selecting leagues", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	s, err := addSeason(ctx, tx, logger, oid, leagueUUID, ns)
+	if err != nil {
+		return Season{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	return s, nil
+}
+
+// UpdateSeasonTeams replaces the organization's own teams in the season.
+// Shared teams and teams of other organizations linked to the league are
+// kept. Teams that already play matches in the season cannot be removed.
+func UpdateSeasonTeams(ctx context.Context, sdb *sqlx.DB, oid string, seasonUUID uuid.UUID, tuuids []uuid.UUID) error {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("season_uuid", seasonUUID.String()),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	defer tx.Rollback()
+
+	ss, err := SelectSeasons(ctx, tx, SeasonFilter{
+		UUID:           seasonUUID,
+		OrganizationID: oid,
+	})
+	switch {
+	case err == nil && len(ss) > 0:
+		// OK.
+	case err == nil && len(ss) == 0:
+		return sbd.NewNotFoundError("season")
+	default:
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	tt, err := selectSeasonTeams(ctx, tx, logger, oid, tuuids)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[uuid.UUID]struct{}, len(tt))
+
+	for _, t := range tt {
+		if t.OrganizationID.V != oid {
+			return sbd.NewValidationError(fmt.Sprintf("team %s is not owned by the organization", t.UUID))
+		}
+
+		keep[t.UUID] = struct{}{}
+	}
+
+	members, err := SelectTeams(ctx, tx, TeamFilter{
+		SeasonUUID:      seasonUUID,
+		IncludeArchived: true,
+	})
+	if err != nil {
+		logger.Error("selecting teams", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	var (
+		removed []uuid.UUID
+		member  = make(map[uuid.UUID]struct{}, len(members))
+	)
+
+	for _, t := range members {
+		member[t.UUID] = struct{}{}
+
+		if t.OrganizationID.V != oid {
+			continue
+		}
+
+		if _, ok := keep[t.UUID]; !ok {
+			removed = append(removed, t.UUID)
+		}
+	}
+
+	if len(removed) > 0 {
+		mm, err := SelectMatches(ctx, tx, MatchFilter{
+			SeasonUUID: seasonUUID,
+			AnyState:   true,
+		}, false)
+		if err != nil {
+			logger.Error("selecting matches", slog.Any("error", err))
+
+			return errInternal
+		}
+
+		for _, m := range mm {
+			for _, tu := range []uuid.UUID{m.HomeTeamUUID, m.AwayTeamUUID} {
+				if slices.Contains(removed, tu) {
+					return sbd.NewValidationError(fmt.Sprintf("team %s has matches in the season", tu))
+				}
+			}
+		}
+
+		if err = deleteSeasonTeams(ctx, tx, seasonUUID, removed); err != nil {
+			logger.Error("deleting season teams", slog.Any("error", err))
+
+			return errInternal
+		}
+	}
+
+	for _, t := range tt {
+		if _, ok := member[t.UUID]; ok {
+			continue
+		}
+
+		if err = insertSeasonTeam(ctx, tx, seasonUUID, t.UUID); err != nil {
+			logger.Error("inserting season team", slog.Any("error", err))
+
+			return errInternal
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return errInternal
+	}
+
+	return nil
+}
+
+// UpdateSeasonTieBreakers replaces the standings tie-breakers of a season of
+// a league linked to the organization.
+func UpdateSeasonTieBreakers(ctx context.Context, sdb *sqlx.DB, oid string, seasonUUID uuid.UUID, tbb TieBreakers) (Season, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("season_uuid", seasonUUID.String()),
+	)
+
+	if err := tbb.Validate(); err != nil {
+		return Season{}, sbd.NewValidationError(err.Error())
+	}
+
+	ss, err := SelectSeasons(ctx, sdb, SeasonFilter{
+		UUID:           seasonUUID,
+		OrganizationID: oid,
+	})
+	switch {
+	case err == nil && len(ss) > 0:
+		// OK.
+	case err == nil && len(ss) == 0:
+		return Season{}, sbd.NewNotFoundError("season")
+	default:
+		logger.Error("selecting seasons", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	s := ss[0]
+	s.StandingsTieBreakers = tbb
+	s.ModifiedAt = time.Now()
+
+	if err = updateSeason(ctx, sdb, s); err != nil {
+		logger.Error("updating season", slog.Any("error", err))
+
+		return Season{}, errInternal
+	}
+
+	return s, nil
+}
+
+// ResolveSeason returns the given season of the league, or its current
+// season when seasonUUID is not set.
+func ResolveSeason(ctx context.Context, sdb *sqlx.DB, oid string, leagueUUID, seasonUUID uuid.UUID) (Season, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("league_uuid", leagueUUID.String()),
+	)
+
+	return resolveSeason(ctx, sdb, logger, oid, leagueUUID, seasonUUID)
+}
@@ -0,0 +1,218 @@
+package scouting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_currentSeason(t *testing.T) {
+	t.Parallel()
+
+	date := func(y int, m time.Month) time.Time {
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	s23 := Season{Name: "2023", StartsAt: date(2023, time.September), EndsAt: date(2024, time.June)}
+	s24 := Season{Name: "2024", StartsAt: date(2024, time.September), EndsAt: date(2025, time.June)}
+
+	tests := map[string]struct {
+		Seasons []Season
+		At      time.Time
+		Season  Season
+		Found   bool
+	}{
+		"no seasons": {
+			At: date(2024, time.October),
+		},
+		"running season": {
+			Seasons: []Season{s24, s23},
+			At:      date(2024, time.October),
+			Season:  s24,
+			Found:   true,
+		},
+		"season start is inclusive": {
+			Seasons: []Season{s24, s23},
+			At:      s24.StartsAt,
+			Season:  s24,
+			Found:   true,
+		},
+		"season end is exclusive": {
+			Seasons: []Season{s23},
+			At:      s23.EndsAt,
+			Season:  s23,
+			Found:   true,
+		},
+		"between seasons": {
+			Seasons: []Season{s23, s24},
+			At:      date(2024, time.July),
+			Season:  s23,
+			Found:   true,
+		},
+		"before first season": {
+			Seasons: []Season{s23, s24},
+			At:      date(2023, time.January),
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			s, ok := currentSeason(tc.Seasons, tc.At)
+			assert.Equal(t, tc.Found, ok)
+			assert.Equal(t, tc.Season, s)
+		})
+	}
+}
+
+func (s *Suite) Test_Seasons() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	home, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "home",
+	})
+	s.Require().NoError(err)
+
+	away, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "away",
+	})
+	s.Require().NoError(err)
+
+	l, current, err := CreateLeague(context.Background(), s.sdb, "o1", NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
+	s.Require().NoError(err)
+
+	err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	s.Run("overlapping season", func() {
+		_, err := CreateSeason(context.Background(), s.sdb, "o1", l.UUID, NewSeason{
+			Name:     "overlap",
+			StartsAt: current.EndsAt.Add(-time.Hour),
+			EndsAt:   current.EndsAt.AddDate(1, 0, 0),
+		})
+		s.Assert().Equal(sbd.NewValidationError(`season overlaps with season "season"`), err)
+	})
+
+	next, err := CreateSeason(context.Background(), s.sdb, "o1", l.UUID, NewSeason{
+		Name:      "next",
+		StartsAt:  current.EndsAt,
+		EndsAt:    current.EndsAt.AddDate(1, 0, 0),
+		TeamUUIDs: []uuid.UUID{home.UUID},
+	})
+	s.Require().NoError(err)
+
+	s.Run("match is linked to the season it starts in", func() {
+		m, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", NewMatch{
+			LeagueUUID:   l.UUID,
+			AwayTeamUUID: away.UUID,
+			HomeTeamUUID: home.UUID,
+			StartsAt:     time.Now().Add(time.Hour),
+		})
+		s.Require().NoError(err)
+		s.Assert().Equal(current.UUID, m.SeasonUUID)
+
+		err = UpdateSeasonTeams(context.Background(), s.sdb, "o1", current.UUID, []uuid.UUID{home.UUID})
+		s.Assert().Equal(sbd.NewValidationError("team "+away.UUID.String()+" has matches in the season"), err)
+	})
+
+	s.Run("team not in the next season", func() {
+		_, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", NewMatch{
+			LeagueUUID:   l.UUID,
+			AwayTeamUUID: away.UUID,
+			HomeTeamUUID: home.UUID,
+			StartsAt:     next.StartsAt.Add(time.Hour),
+		})
+		s.Assert().Equal(sbd.NewValidationError("team not found in season"), err)
+	})
+
+	s.Run("match outside of the given season", func() {
+		_, err := CreateMatch(context.Background(), s.sdb, "o1", "test_scout", NewMatch{
+			LeagueUUID:   l.UUID,
+			SeasonUUID:   next.UUID,
+			AwayTeamUUID: away.UUID,
+			HomeTeamUUID: home.UUID,
+			StartsAt:     time.Now().Add(time.Hour),
+		})
+		s.Assert().Equal(sbd.NewValidationError("match must start within the season"), err)
+	})
+
+	err = UpdateSeasonTeams(context.Background(), s.sdb, "o1", next.UUID, []uuid.UUID{home.UUID, away.UUID})
+	s.Require().NoError(err)
+
+	tt, err := SelectTeams(context.Background(), s.sdb, TeamFilter{
+		SeasonUUID: next.UUID,
+	})
+	s.Require().NoError(err)
+	s.Assert().Len(tt, 2)
+
+	s.Run("duplicate team", func() {
+		err := UpdateSeasonTeams(context.Background(), s.sdb, "o1", next.UUID, []uuid.UUID{home.UUID, home.UUID})
+		s.Assert().Equal(sbd.NewValidationError("duplicate team "+home.UUID.String()), err)
+	})
+
+	s.Run("tie-breakers", func() {
+		_, err := UpdateSeasonTieBreakers(context.Background(), s.sdb, "o1", next.UUID, TieBreakers{"coin_flip"})
+		s.Assert().Equal(sbd.NewValidationError(`tie-breaker 0: unknown tie-breaker "coin_flip"`), err)
+
+		_, err = UpdateSeasonTieBreakers(context.Background(), s.sdb, "o1", next.UUID, TieBreakers{TieBreakerPointDifferential})
+		s.Require().NoError(err)
+
+		ss, err := SelectSeasons(context.Background(), s.sdb, SeasonFilter{
+			UUID: next.UUID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(ss, 1)
+		s.Assert().Equal(TieBreakers{TieBreakerPointDifferential}, ss[0].StandingsTieBreakers)
+
+		cnt := s.selectCount("scouting_config_version", squirrel.Eq{"organization_id": "o1"})
+		s.Assert().Equal(1, cnt)
+	})
+
+	s.Run("teams of other organizations are kept", func() {
+		_, err := CreateOrganization(context.Background(), s.sdb, "o2")
+		s.Require().NoError(err)
+
+		err = UpdateOrganizationLeagues(context.Background(), s.sdb, "o2", []uuid.UUID{l.UUID})
+		s.Require().NoError(err)
+
+		other, err := CreateTeam(context.Background(), s.sdb, "o2", NewTeam{
+			Name: "other",
+		})
+		s.Require().NoError(err)
+
+		shared, err := CreateTeam(context.Background(), s.sdb, "o2", NewTeam{
+			Name:   "shared",
+			Shared: true,
+		})
+		s.Require().NoError(err)
+
+		err = UpdateSeasonTeams(context.Background(), s.sdb, "o2", next.UUID, []uuid.UUID{home.UUID})
+		s.Assert().Equal(sbd.NewValidationError("expected 1 teams, found 0"), err)
+
+		err = UpdateSeasonTeams(context.Background(), s.sdb, "o2", next.UUID, []uuid.UUID{shared.UUID})
+		s.Assert().Equal(sbd.NewValidationError("team "+shared.UUID.String()+" is not owned by the organization"), err)
+
+		err = UpdateSeasonTeams(context.Background(), s.sdb, "o2", next.UUID, []uuid.UUID{other.UUID})
+		s.Require().NoError(err)
+
+		err = UpdateSeasonTeams(context.Background(), s.sdb, "o1", next.UUID, []uuid.UUID{home.UUID})
+		s.Require().NoError(err)
+
+		tt, err := SelectTeams(context.Background(), s.sdb, TeamFilter{
+			SeasonUUID: next.UUID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(tt, 2)
+		s.Assert().ElementsMatch([]uuid.UUID{home.UUID, other.UUID}, []uuid.UUID{tt[0].UUID, tt[1].UUID})
+	})
+}
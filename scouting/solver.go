@@ -0,0 +1,408 @@
+package scouting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+const (
+	// scoutingWindow is how long a match keeps its scouts busy. Matches do
+	// not store an expected end time.
+	scoutingWindow = 2 * time.Hour
+
+	maxProposalRange = 31 * 24 * time.Hour
+)
+
+// ProposedAssignment is a scouting slot the solver suggests assigning.
+type ProposedAssignment struct {
+	MatchUUID uuid.UUID `json:"match_uuid"`
+	AccountID string    `json:"account_id"`
+	Mode      Mode      `json:"mode"`
+	Submode   Submode   `json:"submode"`
+}
+
+func matchesOverlap(a, b Match) bool {
+	return a.StartsAt.Before(b.StartsAt.Add(scoutingWindow)) && b.StartsAt.Before(a.StartsAt.Add(scoutingWindow))
+}
+
+// coverageCandidates returns claimable slots filling coverage gaps of the
+// match, attack before defence and rules before plays. Rules are preferably
+// covered by a single scout.
+func coverageCandidates(mc MatchCoverage) []ScoutSlot {
+	var cands []ScoutSlot
+
+	add := func(m Mode, smm ...Submode) {
+		for _, sm := range smm {
+			if slices.Contains(mc.Claimable, ScoutSlot{Mode: m, Submode: sm}) {
+				cands = append(cands, ScoutSlot{Mode: m, Submode: sm})
+			}
+		}
+	}
+
+	if !mc.AttackRules {
+		add(ModeAttack, SubmodeAllRules, SubmodeOurRules, SubmodeNotOurRules)
+	}
+
+	if !mc.AttackPlays {
+		add(ModeAttack, SubmodePlays)
+	}
+
+	if !mc.DefenceRules {
+		add(ModeDefence, SubmodeAllRules, SubmodeOurRules, SubmodeNotOurRules)
+	}
+
+	if !mc.DefencePlays {
+		add(ModeDefence, SubmodePlays)
+	}
+
+	return cands
+}
+
+// proposeAssignments fills coverage gaps of planned matches in start order.
+// Each slot goes to the account with the fewest slots among those available
+// for the whole scouting window, not involved in the match yet and not busy
+// with an overlapping match. busy holds planned and neighbouring matches that
+// mss refer to.
+func proposeAssignments(plan, busy []Match, mss []MatchScout, aa []Availability, accountIDs []string) []ProposedAssignment {
+	aa = mergeAvailabilities(aa)
+
+	matches := make(map[uuid.UUID]Match, len(busy))
+
+	for _, m := range busy {
+		matches[m.UUID] = m
+	}
+
+	var (
+		claiming = make(map[uuid.UUID][]MatchScout)
+		involved = make(map[uuid.UUID]map[string]struct{})
+		booked   = make(map[string][]Match)
+		load     = make(map[string]int)
+	)
+
+	involve := func(muuid uuid.UUID, aid string) {
+		if involved[muuid] == nil {
+			involved[muuid] = make(map[string]struct{})
+		}
+
+		involved[muuid][aid] = struct{}{}
+	}
+
+	for _, ms := range mss {
+		involve(ms.MatchUUID, ms.AccountID)
+
+		if ms.Status == MatchScoutStatusDeclined {
+			continue
+		}
+
+		claiming[ms.MatchUUID] = append(claiming[ms.MatchUUID], ms)
+		load[ms.AccountID]++
+
+		if m, ok := matches[ms.MatchUUID]; ok {
+			booked[ms.AccountID] = append(booked[ms.AccountID], m)
+		}
+	}
+
+	available := func(aid string, m Match) bool {
+		if _, ok := involved[m.UUID][aid]; ok {
+			return false
+		}
+
+		for _, o := range booked[aid] {
+			if matchesOverlap(m, o) {
+				return false
+			}
+		}
+
+		return availableFor(aa, aid, m)
+	}
+
+	ids := slices.Clone(accountIDs)
+	slices.Sort(ids)
+
+	plan = slices.Clone(plan)
+	slices.SortStableFunc(plan, func(a, b Match) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	var pp []ProposedAssignment
+
+	for _, m := range plan {
+		for {
+			mc := computeCoverage(m, claiming[m.UUID])
+			if mc.Full {
+				break
+			}
+
+			cands := coverageCandidates(mc)
+			if len(cands) == 0 {
+				break
+			}
+
+			var aid string
+
+			for _, id := range ids {
+				if available(id, m) && (aid == "" || load[id] < load[aid]) {
+					aid = id
+				}
+			}
+
+			if aid == "" {
+				break
+			}
+
+			slot := cands[0]
+
+			pp = append(pp, ProposedAssignment{
+				MatchUUID: m.UUID,
+				AccountID: aid,
+				Mode:      slot.Mode,
+				Submode:   slot.Submode,
+			})
+
+			claiming[m.UUID] = append(claiming[m.UUID], MatchScout{
+				MatchUUID: m.UUID,
+				AccountID: aid,
+				Mode:      slot.Mode,
+				Submode:   slot.Submode,
+				Status:    MatchScoutStatusPending,
+			})
+			involve(m.UUID, aid)
+			booked[aid] = append(booked[aid], m)
+			load[aid]++
+		}
+	}
+
+	return pp
+}
+
+// ProposeAssignments suggests scouts for active matches starting within the
+// range. Nothing is stored, the proposal is committed with
+// CommitAssignments.
+func ProposeAssignments(ctx context.Context, sdb *sqlx.DB, oid string, from, to time.Time) ([]ProposedAssignment, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.Time("from", from),
+		slog.Time("to", to),
+	)
+
+	switch {
+	case from.IsZero() || to.IsZero():
+		return nil, sbd.NewValidationError("from and to are required")
+	case !to.After(from):
+		return nil, sbd.NewValidationError("to must be after from")
+	case to.Sub(from) > maxProposalRange:
+		return nil, sbd.NewValidationError("range cannot exceed 31 days")
+	}
+
+	// Matches just outside the range can still double-book a scout.
+	busy, err := SelectMatches(ctx, sdb, MatchFilter{
+		Active:         true,
+		StartsFrom:     from.Add(-scoutingWindow),
+		StartsTo:       to.Add(scoutingWindow),
+		OrganizationID: oid,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	var plan []Match
+
+	muuids := make([]uuid.UUID, len(busy))
+
+	for i, m := range busy {
+		muuids[i] = m.UUID
+
+		if !m.StartsAt.Before(from) && m.StartsAt.Before(to) {
+			plan = append(plan, m)
+		}
+	}
+
+	if len(plan) == 0 {
+		return []ProposedAssignment{}, nil
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, MatchScoutFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+		Statuses:            []MatchScoutStatus{MatchScoutStatusPending, MatchScoutStatusAccepted, MatchScoutStatusDeclined},
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	avv, err := SelectAvailabilities(ctx, sdb, AvailabilityFilter{
+		OrganizationID: oid,
+		From:           from,
+		To:             to.Add(scoutingWindow),
+	})
+	if err != nil {
+		logger.Error("selecting availabilities", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	aa, err := SelectAccounts(ctx, sdb, AccountFilter{
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting accounts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	ids := make([]string, len(aa))
+
+	for i, a := range aa {
+		ids[i] = a.ID
+	}
+
+	pp := proposeAssignments(plan, busy, mss, avv, ids)
+	if pp == nil {
+		pp = []ProposedAssignment{}
+	}
+
+	return pp, nil
+}
+
+// checkAssignedMatches checks that the account is still available for the
+// newly assigned matches and that none of them overlaps another match of the
+// account. Overlaps between matches the account already had are left alone.
+func checkAssignedMatches(aid string, added []uuid.UUID, mm []Match, aa []Availability) error {
+	aa = mergeAvailabilities(aa)
+
+	for _, m := range mm {
+		if !slices.Contains(added, m.UUID) {
+			continue
+		}
+
+		if !availableFor(aa, aid, m) {
+			return fmt.Errorf("account %s is not available for match %s", aid, m.UUID)
+		}
+
+		for _, o := range mm {
+			if o.UUID != m.UUID && matchesOverlap(m, o) {
+				return fmt.Errorf("account %s is double-booked", aid)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CommitAssignments assigns all reviewed proposals as pending slots in a
+// single transaction. Nothing is assigned when any of them is invalid, the
+// account is no longer available or the assignment would double-book it.
+func CommitAssignments(ctx context.Context, sdb *sqlx.DB, oid, aid string, pp []ProposedAssignment) ([]MatchScout, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("account_id", aid),
+	)
+
+	if len(pp) == 0 {
+		return nil, sbd.NewValidationError("assignments are required")
+	}
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	defer tx.Rollback()
+
+	mss := make([]MatchScout, len(pp))
+
+	for i, p := range pp {
+		mss[i], err = assignMatchScout(ctx, tx, logger, oid, aid, p.MatchUUID, NewMatchScoutAssignment{
+			AccountID: p.AccountID,
+			Mode:      p.Mode,
+			Submode:   p.Submode,
+		})
+
+		var ve *sbd.ValidationError
+
+		switch {
+		case err == nil:
+			// OK.
+		case errors.As(err, &ve):
+			return nil, sbd.NewValidationError(fmt.Sprintf("assignments[%d]: %s", i, ve.Error()))
+		default:
+			return nil, err
+		}
+	}
+
+	added := make(map[string][]uuid.UUID)
+
+	for _, p := range pp {
+		added[p.AccountID] = append(added[p.AccountID], p.MatchUUID)
+	}
+
+	ids := slices.Sorted(maps.Keys(added))
+
+	for _, id := range ids {
+		mm, err := SelectMatches(ctx, tx, MatchFilter{
+			Active:         true,
+			ScoutAccountID: id,
+			OrganizationID: oid,
+		}, false)
+		if err != nil {
+			logger.Error("selecting matches", slog.Any("error", err))
+
+			return nil, errInternal
+		}
+
+		var from, to time.Time
+
+		for _, m := range mm {
+			if !slices.Contains(added[id], m.UUID) {
+				continue
+			}
+
+			if from.IsZero() || m.StartsAt.Before(from) {
+				from = m.StartsAt
+			}
+
+			if end := m.StartsAt.Add(scoutingWindow); end.After(to) {
+				to = end
+			}
+		}
+
+		avv, err := SelectAvailabilities(ctx, tx, AvailabilityFilter{
+			OrganizationID: oid,
+			AccountID:      id,
+			From:           from,
+			To:             to,
+		})
+		if err != nil {
+			logger.Error("selecting availabilities", slog.Any("error", err))
+
+			return nil, errInternal
+		}
+
+		if err = checkAssignedMatches(id, added[id], mm, avv); err != nil {
+			return nil, sbd.NewValidationError(err.Error())
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return mss, nil
+}
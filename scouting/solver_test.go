@@ -0,0 +1,191 @@
+package scouting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_proposeAssignments(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC)
+
+	m1 := Match{UUID: uuid.UUID{15: 1}, StartsAt: start}
+	m2 := Match{UUID: uuid.UUID{15: 2}, StartsAt: start.Add(time.Hour)}
+	m3 := Match{UUID: uuid.UUID{15: 3}, StartsAt: start.Add(5 * time.Hour)}
+	m4 := Match{UUID: uuid.UUID{15: 4}, StartsAt: start.Add(8 * time.Hour)}
+
+	mm := []Match{m3, m2, m1, m4}
+
+	mss := []MatchScout{
+		{MatchUUID: m1.UUID, AccountID: "x", Mode: ModeAttack, Submode: SubmodeAllRules, Status: MatchScoutStatusAccepted},
+		{MatchUUID: m4.UUID, AccountID: "a", Mode: ModeAttack, Submode: SubmodeAllRules, Status: MatchScoutStatusDeclined},
+	}
+
+	aa := []Availability{
+		{AccountID: "a", StartsAt: start.Add(-time.Hour), EndsAt: start.Add(12 * time.Hour)},
+		{AccountID: "b", StartsAt: start, EndsAt: start.Add(3 * time.Hour)},
+	}
+
+	pp := proposeAssignments(mm, mm, mss, aa, []string{"c", "b", "a"})
+
+	assert.Equal(t, []ProposedAssignment{
+		// a and b tie on workload, b then has less.
+		{MatchUUID: m1.UUID, AccountID: "a", Mode: ModeAttack, Submode: SubmodePlays},
+		{MatchUUID: m1.UUID, AccountID: "b", Mode: ModeDefence, Submode: SubmodeAllRules},
+		// m2 overlaps m1, b is no longer available for m3 and a declined m4.
+		{MatchUUID: m3.UUID, AccountID: "a", Mode: ModeAttack, Submode: SubmodeAllRules},
+	}, pp)
+}
+
+func Test_mergeAvailabilities(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC)
+
+	aa := mergeAvailabilities([]Availability{
+		{AccountID: "a", StartsAt: start.Add(time.Hour), EndsAt: start.Add(3 * time.Hour)},
+		{AccountID: "b", StartsAt: start, EndsAt: start.Add(time.Hour)},
+		{AccountID: "a", StartsAt: start, EndsAt: start.Add(time.Hour)},
+		{AccountID: "a", StartsAt: start.Add(2 * time.Hour), EndsAt: start.Add(150 * time.Minute)},
+		{AccountID: "a", StartsAt: start.Add(4 * time.Hour), EndsAt: start.Add(5 * time.Hour)},
+		{AccountID: "b", StartsAt: start.Add(time.Hour), EndsAt: start.Add(2 * time.Hour)},
+	})
+
+	assert.Equal(t, []Availability{
+		{AccountID: "a", StartsAt: start, EndsAt: start.Add(3 * time.Hour)},
+		{AccountID: "a", StartsAt: start.Add(4 * time.Hour), EndsAt: start.Add(5 * time.Hour)},
+		{AccountID: "b", StartsAt: start, EndsAt: start.Add(2 * time.Hour)},
+	}, aa)
+
+	// Back to back windows cover a match spanning both.
+	assert.True(t, availableFor(aa, "b", Match{StartsAt: start}))
+	assert.False(t, availableFor(aa, "a", Match{StartsAt: start.Add(2 * time.Hour)}))
+}
+
+func Test_checkAssignedMatches(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC)
+
+	m1 := Match{UUID: uuid.UUID{15: 1}, StartsAt: start}
+	m2 := Match{UUID: uuid.UUID{15: 2}, StartsAt: start.Add(time.Hour)}
+	m3 := Match{UUID: uuid.UUID{15: 3}, StartsAt: start.Add(5 * time.Hour)}
+
+	aa := []Availability{
+		{AccountID: "a", StartsAt: start, EndsAt: start.Add(4 * time.Hour)},
+		{AccountID: "a", StartsAt: start.Add(4 * time.Hour), EndsAt: start.Add(8 * time.Hour)},
+	}
+
+	tests := map[string]struct {
+		Added []uuid.UUID
+		Avail []Availability
+		Error string
+	}{
+		"existing overlap is ignored": {
+			Added: []uuid.UUID{m3.UUID},
+			Avail: aa,
+		},
+		"new match overlaps": {
+			Added: []uuid.UUID{m2.UUID},
+			Avail: aa,
+			Error: "account a is double-booked",
+		},
+		"availability removed": {
+			Added: []uuid.UUID{m3.UUID},
+			Avail: aa[:1],
+			Error: "account a is not available for match " + m3.UUID.String(),
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkAssignedMatches("a", tc.Added, []Match{m1, m2, m3}, tc.Avail)
+			if tc.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, tc.Error)
+		})
+	}
+}
+
+func Test_matchesOverlap(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC)
+
+	a := Match{StartsAt: start}
+
+	assert.True(t, matchesOverlap(a, Match{StartsAt: start.Add(119 * time.Minute)}))
+	assert.False(t, matchesOverlap(a, Match{StartsAt: start.Add(2 * time.Hour)}))
+	assert.False(t, matchesOverlap(a, Match{StartsAt: start.Add(-2 * time.Hour)}))
+}
+
+func (s *Suite) Test_ProposeAndCommitAssignments() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	a := s.createAccount("o1", "1")
+	b := s.createAccount("o1", "2")
+
+	m1 := s.createMatch("o1")
+	m2 := s.createMatch("o1")
+
+	from := time.Now()
+	to := from.Add(24 * time.Hour)
+
+	_, err = CreateAvailability(context.Background(), s.sdb, "o1", a.ID, NewAvailability{
+		StartsAt: from,
+		EndsAt:   to,
+	})
+	s.Require().NoError(err)
+
+	_, err = CreateAvailability(context.Background(), s.sdb, "o1", b.ID, NewAvailability{
+		StartsAt: to,
+		EndsAt:   from,
+	})
+	s.Assert().Equal(sbd.NewValidationError("ends at must be after starts at"), err)
+
+	_, err = ProposeAssignments(context.Background(), s.sdb, "o1", from, from.Add(40*24*time.Hour))
+	s.Assert().Equal(sbd.NewValidationError("range cannot exceed 31 days"), err)
+
+	pp, err := ProposeAssignments(context.Background(), s.sdb, "o1", from, to)
+	s.Require().NoError(err)
+
+	// Both matches overlap, so a single available scout only gets one slot.
+	s.Require().Len(pp, 1)
+	s.Assert().Equal(a.ID, pp[0].AccountID)
+
+	other := m1.UUID
+	if pp[0].MatchUUID == m1.UUID {
+		other = m2.UUID
+	}
+
+	_, err = CommitAssignments(context.Background(), s.sdb, "o1", "coordinator", append(pp, ProposedAssignment{
+		MatchUUID: other,
+		AccountID: a.ID,
+		Mode:      ModeDefence,
+		Submode:   SubmodePlays,
+	}))
+	s.Assert().Equal(sbd.NewValidationError("account 1 is double-booked"), err)
+	s.Assert().Equal(0, s.selectCount("match_scout", squirrel.Eq{"account_id": a.ID}))
+
+	mss, err := CommitAssignments(context.Background(), s.sdb, "o1", "coordinator", pp)
+	s.Require().NoError(err)
+	s.Require().Len(mss, 1)
+	s.Assert().Equal(MatchScoutStatusPending, mss[0].Status)
+
+	_, err = CommitAssignments(context.Background(), s.sdb, "o1", "coordinator", pp)
+	s.Assert().Equal(sbd.NewValidationError("assignments[0]: account already scouting this match"), err)
+}
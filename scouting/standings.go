@@ -0,0 +1,316 @@
+package scouting
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// TieBreaker orders teams with the same record in league standings.
+type TieBreaker string
+
+const (
+	// TieBreakerHeadToHead ranks tied teams by wins in matches played
+	// between them.
+	TieBreakerHeadToHead TieBreaker = "head_to_head"
+	// TieBreakerPointDifferential ranks tied teams by overall point
+	// differential.
+	TieBreakerPointDifferential TieBreaker = "point_differential"
+)
+
+// DefaultTieBreakers are used when the season does not list any.
+var DefaultTieBreakers = []TieBreaker{TieBreakerHeadToHead, TieBreakerPointDifferential}
+
+func (tb TieBreaker) valid() bool {
+	switch tb {
+	case TieBreakerHeadToHead, TieBreakerPointDifferential:
+		return true
+	default:
+		return false
+	}
+}
+
+// TieBreakers are the tie-breakers of a season in the order they apply.
+type TieBreakers []TieBreaker
+
+func (tbb TieBreakers) Validate() error {
+	seen := make(map[TieBreaker]struct{}, len(tbb))
+
+	for i, tb := range tbb {
+		if !tb.valid() {
+			return fmt.Errorf("tie-breaker %d: unknown tie-breaker %q", i, tb)
+		}
+
+		if _, ok := seen[tb]; ok {
+			return fmt.Errorf("tie-breaker %d: duplicate tie-breaker %q", i, tb)
+		}
+
+		seen[tb] = struct{}{}
+	}
+
+	return nil
+}
+
+func (tbb TieBreakers) Value() (driver.Value, error) {
+	if tbb == nil {
+		tbb = TieBreakers{}
+	}
+
+	return json.Marshal([]TieBreaker(tbb))
+}
+
+func (tbb *TieBreakers) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, tbb)
+	case string:
+		return json.Unmarshal([]byte(v), tbb)
+	default:
+		return errors.New("unsupported tie-breakers source type")
+	}
+}
+
+// Record counts wins and losses.
+type Record struct {
+	Wins   uint
+	Losses uint
+}
+
+// Standing is a row of the league table. Streak is positive for consecutive
+// wins and negative for consecutive losses.
+type Standing struct {
+	TeamUUID      uuid.UUID
+	Played        uint
+	Wins          uint
+	Losses        uint
+	PointsFor     uint
+	PointsAgainst uint
+	Home          Record
+	Away          Record
+	Streak        int
+}
+
+func (s *Standing) PointDifferential() int {
+	return int(s.PointsFor) - int(s.PointsAgainst)
+}
+
+// WinPercentage is the share of decided matches won, 0 when none were
+// decided.
+func (s *Standing) WinPercentage() float64 {
+	if s.Wins+s.Losses == 0 {
+		return 0
+	}
+
+	return float64(s.Wins) / float64(s.Wins+s.Losses)
+}
+
+// compareWinPercentage orders standings by win percentage, highest first. It
+// compares exact ratios so that equal records of different length tie.
+func compareWinPercentage(a, b Standing) int {
+	ad, bd := max(a.Wins+a.Losses, 1), max(b.Wins+b.Losses, 1)
+
+	return cmp.Compare(b.Wins*ad, a.Wins*bd)
+}
+
+func (s *Standing) add(pointsFor, pointsAgainst uint, home bool) {
+	s.Played++
+	s.PointsFor += pointsFor
+	s.PointsAgainst += pointsAgainst
+
+	rec := &s.Away
+	if home {
+		rec = &s.Home
+	}
+
+	switch {
+	case pointsFor > pointsAgainst:
+		s.Wins++
+		rec.Wins++
+
+		s.Streak = max(s.Streak, 0) + 1
+	case pointsFor < pointsAgainst:
+		s.Losses++
+		rec.Losses++
+
+		s.Streak = min(s.Streak, 0) - 1
+	default:
+		s.Streak = 0
+	}
+}
+
+// computeStandings builds the table of the given teams from finished
+// matches. Teams are ranked by win percentage, then by tie-breakers in the
+// given order and finally by uuid so that the order is stable.
+func computeStandings(tuuids []uuid.UUID, mm []Match, tbb []TieBreaker) []Standing {
+	played := slices.Clone(mm)
+
+	slices.SortFunc(played, func(a, b Match) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	standings := make(map[uuid.UUID]*Standing, len(tuuids))
+
+	for _, tu := range tuuids {
+		standings[tu] = &Standing{TeamUUID: tu}
+	}
+
+	for _, m := range played {
+		if !m.FinishedAt.Valid || !m.HomeScore.Valid || !m.AwayScore.Valid {
+			continue
+		}
+
+		home, ok := standings[m.HomeTeamUUID]
+		if !ok {
+			continue
+		}
+
+		away, ok := standings[m.AwayTeamUUID]
+		if !ok {
+			continue
+		}
+
+		home.add(m.HomeScore.V, m.AwayScore.V, true)
+		away.add(m.AwayScore.V, m.HomeScore.V, false)
+	}
+
+	ss := make([]Standing, 0, len(standings))
+
+	for _, tu := range tuuids {
+		ss = append(ss, *standings[tu])
+	}
+
+	slices.SortFunc(ss, compareWinPercentage)
+
+	// Tie-breakers only apply within groups of teams with the same win
+	// percentage.
+	for i := 0; i < len(ss); {
+		j := i + 1
+
+		for j < len(ss) && compareWinPercentage(ss[i], ss[j]) == 0 {
+			j++
+		}
+
+		breakTies(ss[i:j], played, tbb)
+
+		i = j
+	}
+
+	return ss
+}
+
+func breakTies(group []Standing, mm []Match, tbb []TieBreaker) {
+	h2h := make(map[uuid.UUID]uint, len(group))
+
+	if slices.Contains(tbb, TieBreakerHeadToHead) && len(group) > 1 {
+		tied := make(map[uuid.UUID]struct{}, len(group))
+
+		for _, s := range group {
+			tied[s.TeamUUID] = struct{}{}
+		}
+
+		for _, m := range mm {
+			if !m.FinishedAt.Valid || !m.HomeScore.Valid || !m.AwayScore.Valid {
+				continue
+			}
+
+			_, homeTied := tied[m.HomeTeamUUID]
+			_, awayTied := tied[m.AwayTeamUUID]
+
+			if !homeTied || !awayTied {
+				continue
+			}
+
+			switch {
+			case m.HomeScore.V > m.AwayScore.V:
+				h2h[m.HomeTeamUUID]++
+			case m.HomeScore.V < m.AwayScore.V:
+				h2h[m.AwayTeamUUID]++
+			}
+		}
+	}
+
+	slices.SortStableFunc(group, func(a, b Standing) int {
+		for _, tb := range tbb {
+			switch tb {
+			case TieBreakerHeadToHead:
+				if h2h[a.TeamUUID] != h2h[b.TeamUUID] {
+					return int(h2h[b.TeamUUID]) - int(h2h[a.TeamUUID])
+				}
+			case TieBreakerPointDifferential:
+				if a.PointDifferential() != b.PointDifferential() {
+					return b.PointDifferential() - a.PointDifferential()
+				}
+			}
+		}
+
+		return bytes.Compare(a.TeamUUID.Bytes(), b.TeamUUID.Bytes())
+	})
+}
+
+// LeagueStandings returns the table of a league season, the current one when
+// seasonUUID is not set, using tie-breakers of the season.
+func LeagueStandings(ctx context.Context, sdb *sqlx.DB, oid string, leagueUUID, seasonUUID uuid.UUID) (Season, []Standing, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("league_uuid", leagueUUID.String()),
+	)
+
+	ll, err := SelectLeagues(ctx, sdb, LeagueFilter{
+		LeagueUUID:     leagueUUID,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(ll) > 0:
+		// OK.
+	case err == nil && len(ll) == 0:
+		return Season{}, nil, sbd.NewNotFoundError("league")
+	default:
+		logger.Error("selecting leagues", slog.Any("error", err))
+
+		return Season{}, nil, errInternal
+	}
+
+	season, err := resolveSeason(ctx, sdb, logger, oid, leagueUUID, seasonUUID)
+	if err != nil {
+		return Season{}, nil, err
+	}
+
+	tt, err := SelectTeams(ctx, sdb, TeamFilter{
+		SeasonUUID:      season.UUID,
+		IncludeArchived: true,
+	})
+	if err != nil {
+		logger.Error("selecting teams", slog.Any("error", err))
+
+		return Season{}, nil, errInternal
+	}
+
+	tuuids := make([]uuid.UUID, len(tt))
+
+	for i, t := range tt {
+		tuuids[i] = t.UUID
+	}
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		LeagueUUID:     leagueUUID,
+		SeasonUUID:     season.UUID,
+		OrganizationID: oid,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return Season{}, nil, errInternal
+	}
+
+	return season, computeStandings(tuuids, mm, season.tieBreakers()), nil
+}
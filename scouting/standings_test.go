@@ -0,0 +1,186 @@
+package scouting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeStandings(t *testing.T) {
+	t.Parallel()
+
+	// a < b < c < d for the final uuid fallback.
+	a := uuid.UUID{15: 1}
+	b := uuid.UUID{15: 2}
+	c := uuid.UUID{15: 3}
+	d := uuid.UUID{15: 4}
+
+	start := time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC)
+	day := 0
+
+	match := func(home, away uuid.UUID, hs, as uint) Match {
+		day++
+
+		return Match{
+			HomeTeamUUID: home,
+			AwayTeamUUID: away,
+			HomeScore:    null.NewValue(hs, true),
+			AwayScore:    null.NewValue(as, true),
+			StartsAt:     start.AddDate(0, 0, day),
+			FinishedAt:   null.NewValue(start.AddDate(0, 0, day), true),
+		}
+	}
+
+	// a and b both go 2-1, b beat a head to head while a has the better
+	// point differential.
+	mm := []Match{
+		match(a, b, 70, 80),
+		match(a, c, 90, 60),
+		match(b, c, 70, 75),
+		match(d, a, 60, 100),
+		match(b, d, 65, 60),
+	}
+
+	tests := map[string]struct {
+		TieBreakers []TieBreaker
+		Order       []uuid.UUID
+	}{
+		"head to head first": {
+			TieBreakers: []TieBreaker{TieBreakerHeadToHead, TieBreakerPointDifferential},
+			Order:       []uuid.UUID{b, a, c, d},
+		},
+		"point differential first": {
+			TieBreakers: []TieBreaker{TieBreakerPointDifferential, TieBreakerHeadToHead},
+			Order:       []uuid.UUID{a, b, c, d},
+		},
+		"no tie-breakers": {
+			Order: []uuid.UUID{a, b, c, d},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			ss := computeStandings([]uuid.UUID{d, c, b, a}, mm, tc.TieBreakers)
+
+			order := make([]uuid.UUID, len(ss))
+
+			for i, s := range ss {
+				order[i] = s.TeamUUID
+			}
+
+			assert.Equal(t, tc.Order, order)
+		})
+	}
+
+	ss := computeStandings([]uuid.UUID{a, b, c, d}, mm, DefaultTieBreakers)
+
+	assert.Equal(t, Standing{
+		TeamUUID:      a,
+		Played:        3,
+		Wins:          2,
+		Losses:        1,
+		PointsFor:     260,
+		PointsAgainst: 200,
+		Home:          Record{Wins: 1, Losses: 1},
+		Away:          Record{Wins: 1},
+		Streak:        2,
+	}, ss[1])
+	assert.Equal(t, 60, ss[1].PointDifferential())
+
+	assert.Equal(t, Standing{
+		TeamUUID:      d,
+		Played:        2,
+		Losses:        2,
+		PointsFor:     120,
+		PointsAgainst: 165,
+		Home:          Record{Losses: 1},
+		Away:          Record{Losses: 1},
+		Streak:        -2,
+	}, ss[3])
+}
+
+func Test_compareWinPercentage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		A      Standing
+		B      Standing
+		Result int
+	}{
+		"better percentage with fewer wins": {
+			A:      Standing{Wins: 3},
+			B:      Standing{Wins: 4, Losses: 2},
+			Result: -1,
+		},
+		"same percentage with different records": {
+			A:      Standing{Wins: 2, Losses: 1},
+			B:      Standing{Wins: 4, Losses: 2},
+			Result: 0,
+		},
+		"worse percentage": {
+			A:      Standing{Wins: 1, Losses: 2},
+			B:      Standing{Wins: 1, Losses: 1},
+			Result: 1,
+		},
+		"no decided matches": {
+			A:      Standing{},
+			B:      Standing{Wins: 1, Losses: 3},
+			Result: 1,
+		},
+		"no decided matches against winless": {
+			A:      Standing{},
+			B:      Standing{Losses: 3},
+			Result: 0,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Result, compareWinPercentage(tc.A, tc.B))
+		})
+	}
+}
+
+func Test_TieBreakersValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		TieBreakers TieBreakers
+		Error       string
+	}{
+		"empty": {},
+		"valid": {
+			TieBreakers: TieBreakers{TieBreakerPointDifferential, TieBreakerHeadToHead},
+		},
+		"unknown": {
+			TieBreakers: TieBreakers{TieBreakerHeadToHead, "coin_flip"},
+			Error:       `tie-breaker 1: unknown tie-breaker "coin_flip"`,
+		},
+		"duplicate": {
+			TieBreakers: TieBreakers{TieBreakerHeadToHead, TieBreakerHeadToHead},
+			Error:       `tie-breaker 1: duplicate tie-breaker "head_to_head"`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.TieBreakers.Validate()
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package scouting
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// StatisticTagFilter narrows down possessions counted towards statistic
+// tags. TeamUUID selects possessions in which the team had the ball, while
+// StartsFrom and StartsTo filter by match start time. A league without a
+// season, match or time range is narrowed down to its current season.
+type StatisticTagFilter struct {
+	MatchUUID  uuid.UUID
+	LeagueUUID uuid.UUID
+	SeasonUUID uuid.UUID
+	TeamUUID   uuid.UUID
+	Mode       Mode
+	StartsFrom time.Time
+	StartsTo   time.Time
+}
+
+type StatisticTagCount struct {
+	Tag   string
+	Count uint
+}
+
+func (f *StatisticTagFilter) Validate() error {
+	switch f.Mode {
+	case "", ModeAttack, ModeDefence:
+		// OK.
+	default:
+		return sbd.NewValidationError("mode must be attack or defence")
+	}
+
+	if !f.StartsFrom.IsZero() && !f.StartsTo.IsZero() && !f.StartsFrom.Before(f.StartsTo) {
+		return sbd.NewValidationError("from must be before to")
+	}
+
+	return nil
+}
+
+func (f *StatisticTagFilter) defaultsToCurrentSeason() bool {
+	return !f.LeagueUUID.IsNil() &&
+		f.SeasonUUID.IsNil() &&
+		f.MatchUUID.IsNil() &&
+		f.StartsFrom.IsZero() &&
+		f.StartsTo.IsZero()
+}
+
+// countStatisticTags counts statistic tags of outcomes as generic counters,
+// so tags added by organizations are reported without extra configuration.
+func countStatisticTags(cs configSet, mm []Match, mss []MatchScout, pp []Possession, f StatisticTagFilter) []StatisticTagCount {
+	matches := make(map[uuid.UUID]Match, len(mm))
+
+	for _, m := range mm {
+		matches[m.UUID] = m
+	}
+
+	counts := make(map[string]uint)
+
+	for _, p := range countedPossessions(mss, pp) {
+		m, ok := matches[p.MatchUUID]
+		if !ok {
+			continue
+		}
+
+		if f.Mode != "" && p.Mode != f.Mode {
+			continue
+		}
+
+		if !f.TeamUUID.IsNil() && offenceTeam(m, p) != f.TeamUUID {
+			continue
+		}
+
+		o, ok := cs.outcome(p)
+		if !ok {
+			continue
+		}
+
+		for _, t := range o.StatisticTags {
+			counts[t]++
+		}
+	}
+
+	tcc := make([]StatisticTagCount, 0, len(counts))
+
+	for t, c := range counts {
+		tcc = append(tcc, StatisticTagCount{Tag: t, Count: c})
+	}
+
+	sort.Slice(tcc, func(i, j int) bool {
+		if tcc[i].Count != tcc[j].Count {
+			return tcc[i].Count > tcc[j].Count
+		}
+
+		return tcc[i].Tag < tcc[j].Tag
+	})
+
+	return tcc
+}
+
+func StatisticTagCounts(ctx context.Context, sdb *sqlx.DB, oid string, f StatisticTagFilter) ([]StatisticTagCount, error) {
+	logger := slog.With(slog.String("organization_id", oid))
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	if f.defaultsToCurrentSeason() {
+		season, err := resolveSeason(ctx, sdb, logger, oid, f.LeagueUUID, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+
+		f.SeasonUUID = season.UUID
+	}
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		AnyState:       true,
+		UUID:           f.MatchUUID,
+		LeagueUUID:     f.LeagueUUID,
+		SeasonUUID:     f.SeasonUUID,
+		TeamUUID:       f.TeamUUID,
+		StartsFrom:     f.StartsFrom,
+		StartsTo:       f.StartsTo,
+		OrganizationID: oid,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	if len(mm) == 0 {
+		return []StatisticTagCount{}, nil
+	}
+
+	muuids := make([]uuid.UUID, len(mm))
+
+	for i, m := range mm {
+		muuids[i] = m.UUID
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, MatchScoutFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	pp, err := SelectPossessions(ctx, sdb, PossessionFilter{
+		MatchUUIDs:          muuids,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	vv, err := selectScoutingConfigVersions(ctx, sdb, ScoutingConfigVersionFilter{
+		OrganizationID: oid,
+	})
+	if err != nil {
+		logger.Error("selecting scouting config versions", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return countStatisticTags(newConfigSet(vv, mss), mm, mss, pp, f), nil
+}
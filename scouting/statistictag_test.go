@@ -0,0 +1,73 @@
+package scouting
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_countStatisticTags(t *testing.T) {
+	t.Parallel()
+
+	home := uuid.Must(uuid.NewV7())
+	away := uuid.Must(uuid.NewV7())
+	finished := null.NewValue(time.Now(), true)
+
+	m := Match{
+		UUID:            uuid.Must(uuid.NewV7()),
+		HomeTeamUUID:    home,
+		AwayTeamUUID:    away,
+		ScoutedTeamUUID: home,
+	}
+
+	mss := []MatchScout{
+		{MatchUUID: m.UUID, AccountID: "a", Mode: ModeAttackDefence, Submode: SubmodeAnyRules, FinishedAt: finished},
+	}
+
+	cfg := DefaultScoutingConfig
+	cfg.Outcomes = append(slices.Clone(cfg.Outcomes), Outcome{
+		ID:            "charge",
+		StatisticTags: []string{"offensive foul"},
+	})
+
+	possession := func(mode Mode, outcome string) Possession {
+		return Possession{MatchUUID: m.UUID, AccountID: "a", Mode: mode, OutcomeID: outcome}
+	}
+
+	pp := []Possession{
+		possession(ModeAttack, "o2 + foul"),
+		possession(ModeAttack, "charge"),
+		possession(ModeDefence, "smart foul"),
+		possession(ModeDefence, "o2"),
+	}
+
+	t.Run("all", func(t *testing.T) {
+		assert.Equal(t, []StatisticTagCount{
+			{Tag: "foul", Count: 2},
+			{Tag: "and one shooting foul", Count: 1},
+			{Tag: "offensive foul", Count: 1},
+			{Tag: "shooting foul", Count: 1},
+			{Tag: "smart foul", Count: 1},
+		}, countStatisticTags(singleConfigSet(cfg, mss), []Match{m}, mss, pp, StatisticTagFilter{}))
+	})
+
+	t.Run("by mode", func(t *testing.T) {
+		assert.Equal(t, []StatisticTagCount{
+			{Tag: "foul", Count: 1},
+			{Tag: "smart foul", Count: 1},
+		}, countStatisticTags(singleConfigSet(cfg, mss), []Match{m}, mss, pp, StatisticTagFilter{Mode: ModeDefence}))
+	})
+
+	t.Run("by team", func(t *testing.T) {
+		assert.Equal(t, []StatisticTagCount{
+			{Tag: "and one shooting foul", Count: 1},
+			{Tag: "foul", Count: 1},
+			{Tag: "offensive foul", Count: 1},
+			{Tag: "shooting foul", Count: 1},
+		}, countStatisticTags(singleConfigSet(cfg, mss), []Match{m}, mss, pp, StatisticTagFilter{TeamUUID: home}))
+	})
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
 	"github.com/jmoiron/sqlx"
 	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/suite"
@@ -57,12 +60,21 @@ func TestDBSuite(t *testing.T) {
 func (s *Suite) TearDownTest() {
 	tables := []string{
 		"organization_league",
-		"league_team",
+		"possession",
+		"released_possession",
 		"match_scout",
+		"match_reconciliation",
+		"match_reschedule",
+		"match_scout_audit",
 		"match",
+		"season_team",
+		"season",
 		"league",
 		"team",
 		"organization_account",
+		"scouting_config_version",
+		"calendar_token",
+		"availability",
 		"account",
 		"organization",
 	}
@@ -113,3 +125,115 @@ func (s *Suite) selectCount(table string, pred any) int {
 
 	return cnt
 }
+
+func (s *Suite) createAccount(oid, id string) Account {
+	s.T().Helper()
+
+	name := "john"
+	lastName := "mayor"
+	avatarURL := "https://x.com"
+
+	a, err := OnboardAccount(context.Background(), s.sdb, oid, &clerk.User{
+		ID:        id,
+		FirstName: &name,
+		LastName:  &lastName,
+		ImageURL:  &avatarURL,
+	})
+	s.Require().NoError(err)
+
+	return a
+}
+
+// legacyScoutingConfig is the default config organizations were created with
+// before configs were validated. Its Detailed layout lists actions the config
+// never defined.
+func legacyScoutingConfig() ScoutingConfig {
+	cfg := DefaultScoutingConfig
+	cfg.Layouts = []Layout{
+		DefaultScoutingConfig.Layouts[0],
+		{
+			Name: "Detailed",
+			Actions: []string{
+				"1x1", "fb", "put back", "tw5", "x all", "off scr", "tw4", "lp", "zone",
+				"sw5", "g to g", "press", "sw4", "special situation", "other",
+			},
+		},
+	}
+
+	return cfg
+}
+
+// createLegacyOrganization creates an organization as it was stored before
+// configs were validated. Migrations are applied with migrateLegacy.
+func (s *Suite) createLegacyOrganization(oid string) {
+	s.T().Helper()
+
+	_, err := CreateOrganization(context.Background(), s.sdb, oid)
+	s.Require().NoError(err)
+
+	cfg := legacyScoutingConfig()
+
+	_, err = s.sdb.ExecContext(context.Background(), "UPDATE organization SET scouting_config = $1 WHERE id = $2", cfg, oid)
+	s.Require().NoError(err)
+
+	_, err = s.sdb.ExecContext(context.Background(), "UPDATE scouting_config_version SET scouting_config = $1 WHERE organization_id = $2", cfg, oid)
+	s.Require().NoError(err)
+}
+
+// migrateLegacy reapplies the migration repairing configs stored before
+// validation.
+func (s *Suite) migrateLegacy() {
+	s.T().Helper()
+
+	sql, err := migrations.ReadFile("migrations/11_scouting_config_layout_actions.up.sql")
+	s.Require().NoError(err)
+
+	_, err = s.sdb.ExecContext(context.Background(), string(sql))
+	s.Require().NoError(err)
+}
+
+// testSeason describes a season of the given teams running from an hour ago
+// for a year.
+func testSeason(tuuids ...uuid.UUID) NewSeason {
+	return NewSeason{
+		Name:      "season",
+		StartsAt:  time.Now().Add(-time.Hour),
+		EndsAt:    time.Now().AddDate(1, 0, 0),
+		TeamUUIDs: tuuids,
+	}
+}
+
+// createMatch creates a league with two teams linked to the organization
+// and schedules a match between them.
+func (s *Suite) createMatch(oid string) Match {
+	s.T().Helper()
+
+	home, err := CreateTeam(context.Background(), s.sdb, oid, NewTeam{
+		Name: "home",
+	})
+	s.Require().NoError(err)
+
+	away, err := CreateTeam(context.Background(), s.sdb, oid, NewTeam{
+		Name: "away",
+	})
+	s.Require().NoError(err)
+
+	l, _, err := CreateLeague(context.Background(), s.sdb, oid, NewLeague{
+		Name:   "league",
+		Season: testSeason(home.UUID, away.UUID),
+	})
+	s.Require().NoError(err)
+
+	err = UpdateOrganizationLeagues(context.Background(), s.sdb, oid, []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	m, err := CreateMatch(context.Background(), s.sdb, oid, "test_scout", NewMatch{
+		LeagueUUID:   l.UUID,
+		AwayTeamUUID: away.UUID,
+		HomeTeamUUID: home.UUID,
+		StartsAt:     time.Now().Add(time.Hour),
+	})
+	s.Require().NoError(err)
+
+	return m
+}
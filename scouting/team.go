@@ -2,47 +2,216 @@ package scouting
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
 	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
 )
 
+// NewTeam describes a team owned by the creating organization. Shared teams
+// have no owner and are visible to all organizations.
 type NewTeam struct {
-	Name string `json:"name"`
+	Name   string `json:"name"`
+	Shared bool   `json:"shared"`
 }
 
+// TeamFilter returns teams visible to OrganizationID, i.e. owned by it or
+// shared, when set. Archived teams are only returned with IncludeArchived.
+// When Limit is set, teams are ordered by uuid and start after the After uuid.
 type TeamFilter struct {
-	UUIDs          []uuid.UUID
-	LeagueUUID     uuid.UUID
-	OrganizationID string
+	UUIDs           []uuid.UUID
+	LeagueUUID      uuid.UUID
+	SeasonUUID      uuid.UUID
+	OrganizationID  string
+	IncludeArchived bool
+	After           uuid.UUID
+	Limit           uint64
 }
 
-func (nt *NewTeam) ToTeam() Team {
+func (nt *NewTeam) Validate() error {
+	if nt.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return nil
+}
+
+func (nt *NewTeam) ToTeam(oid string) Team {
 	tnow := time.Now()
 
 	return Team{
-		UUID:       uuid.Must(uuid.NewV7()),
-		Name:       nt.Name,
-		CreatedAt:  tnow,
-		ModifiedAt: tnow,
+		UUID:           uuid.Must(uuid.NewV7()),
+		Name:           nt.Name,
+		OrganizationID: null.NewValue(oid, !nt.Shared),
+		CreatedAt:      tnow,
+		ModifiedAt:     tnow,
 	}
 }
 
 type Team struct {
-	UUID uuid.UUID `db:"team.uuid"`
-	Name string    `db:"team.name"`
+	UUID           uuid.UUID             `db:"team.uuid"`
+	Name           string                `db:"team.name"`
+	OrganizationID null.Value[string]    `db:"team.organization_id"`
+	ArchivedAt     null.Value[time.Time] `db:"team.archived_at"`
 
 	CreatedAt  time.Time `db:"team.created_at"`
 	ModifiedAt time.Time `db:"team.modified_at"`
 }
 
-func CreateTeam(ctx context.Context, nt NewTeam, sdb *sqlx.DB) (Team, error) {
-	t := nt.ToTeam()
+// TeamUpdate holds fields of a team that should be changed.
+type TeamUpdate struct {
+	Name *string `json:"name"`
+}
+
+func (tu *TeamUpdate) Validate() error {
+	if tu.Name != nil && *tu.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	return nil
+}
+
+func CreateTeam(ctx context.Context, sdb *sqlx.DB, oid string, nt NewTeam) (Team, error) {
+	if err := nt.Validate(); err != nil {
+		return Team{}, sbd.NewValidationError(err.Error())
+	}
+
+	t := nt.ToTeam(oid)
 
 	if err := insertTeam(ctx, sdb, t); err != nil {
-		return Team{}, fmt.Errorf("inserting team: %w", err)
+		slog.Error("inserting team", slog.Any("error", err), slog.String("organization_id", oid))
+
+		return Team{}, errInternal
+	}
+
+	return t, nil
+}
+
+// selectOwnedTeam returns a non archived team owned by the organization.
+// Shared teams can only be used, not modified.
+func selectOwnedTeam(ctx context.Context, tx *sqlx.Tx, logger *slog.Logger, oid string, teamUUID uuid.UUID) (Team, error) {
+	tt, err := SelectTeams(ctx, tx, TeamFilter{
+		UUIDs:          []uuid.UUID{teamUUID},
+		OrganizationID: oid,
+	})
+	switch {
+	case err == nil && len(tt) > 0:
+		// OK.
+	case err == nil && len(tt) == 0:
+		return Team{}, sbd.NewNotFoundError("team")
+	default:
+		logger.Error("selecting teams", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	if !tt[0].OrganizationID.Valid {
+		return Team{}, sbd.NewValidationError("shared team cannot be modified")
+	}
+
+	return tt[0], nil
+}
+
+func UpdateTeam(ctx context.Context, sdb *sqlx.DB, oid string, teamUUID uuid.UUID, tu TeamUpdate) (Team, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("team_uuid", teamUUID.String()),
+	)
+
+	if err := tu.Validate(); err != nil {
+		return Team{}, sbd.NewValidationError(err.Error())
+	}
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	t, err := selectOwnedTeam(ctx, tx, logger, oid, teamUUID)
+	if err != nil {
+		return Team{}, err
+	}
+
+	if tu.Name != nil {
+		t.Name = *tu.Name
+	}
+
+	t.ModifiedAt = time.Now()
+
+	if err = updateTeam(ctx, tx, t); err != nil {
+		logger.Error("updating team", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	return t, nil
+}
+
+// ArchiveTeam hides the team from listings and new leagues and matches. It
+// fails while the team has active matches.
+func ArchiveTeam(ctx context.Context, sdb *sqlx.DB, oid string, teamUUID uuid.UUID) (Team, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("team_uuid", teamUUID.String()),
+	)
+
+	tx, err := sdb.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Error("beginning tx", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	defer tx.Rollback()
+
+	t, err := selectOwnedTeam(ctx, tx, logger, oid, teamUUID)
+	if err != nil {
+		return Team{}, err
+	}
+
+	mm, err := SelectMatches(ctx, tx, MatchFilter{
+		Active:   true,
+		TeamUUID: t.UUID,
+	}, false)
+	if err != nil {
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	if len(mm) > 0 {
+		return Team{}, sbd.NewValidationError("team has active matches")
+	}
+
+	tnow := time.Now()
+
+	t.ArchivedAt = null.NewValue(tnow, true)
+	t.ModifiedAt = tnow
+
+	if err = updateTeam(ctx, tx, t); err != nil {
+		logger.Error("updating team", slog.Any("error", err))
+
+		return Team{}, errInternal
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("commiting", slog.Any("error", err))
+
+		return Team{}, errInternal
 	}
 
 	return t, nil
@@ -4,12 +4,15 @@ import (
 	"context"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
 )
 
 func (s *Suite) Test_CreateTeam() {
-	t, err := CreateTeam(context.Background(), NewTeam{
-		Name: "test",
-	}, s.sdb)
+	t, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "test",
+		Shared: true,
+	})
 	s.Require().NoError(err)
 
 	s.Assert().Equal("test", t.Name)
@@ -23,3 +26,104 @@ func (s *Suite) Test_CreateTeam() {
 
 	s.Assert().Equal(1, cnt)
 }
+
+func (s *Suite) Test_SelectTeams_Visibility() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	_, err = CreateOrganization(context.Background(), s.sdb, "o2")
+	s.Require().NoError(err)
+
+	shared, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "shared",
+		Shared: true,
+	})
+	s.Require().NoError(err)
+
+	owned, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "owned",
+	})
+	s.Require().NoError(err)
+
+	_, err = CreateTeam(context.Background(), s.sdb, "o2", NewTeam{
+		Name: "other",
+	})
+	s.Require().NoError(err)
+
+	tt, err := SelectTeams(context.Background(), s.sdb, TeamFilter{
+		OrganizationID: "o1",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(tt, 2)
+	s.Assert().ElementsMatch([]uuid.UUID{shared.UUID, owned.UUID}, []uuid.UUID{tt[0].UUID, tt[1].UUID})
+
+	_, _, err = CreateLeague(context.Background(), s.sdb, "o2", NewLeague{
+		Name:   "league",
+		Season: testSeason(owned.UUID),
+	})
+	s.Assert().Equal(sbd.NewValidationError("expected 1 teams, found 0"), err)
+}
+
+func (s *Suite) Test_UpdateTeam() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	t, err := CreateTeam(context.Background(), s.sdb, "o1", NewTeam{
+		Name: "team",
+	})
+	s.Require().NoError(err)
+
+	name := "renamed"
+
+	t, err = UpdateTeam(context.Background(), s.sdb, "o1", t.UUID, TeamUpdate{Name: &name})
+	s.Require().NoError(err)
+	s.Assert().Equal("renamed", t.Name)
+
+	cnt := s.selectCount("team", squirrel.Eq{"uuid": t.UUID, "name": "renamed"})
+	s.Assert().Equal(1, cnt)
+
+	_, err = UpdateTeam(context.Background(), s.sdb, "o2", t.UUID, TeamUpdate{Name: &name})
+	s.Assert().Equal(sbd.NewNotFoundError("team"), err)
+
+	shared, err := CreateTeam(context.Background(), s.sdb, "", NewTeam{
+		Name:   "shared",
+		Shared: true,
+	})
+	s.Require().NoError(err)
+
+	_, err = UpdateTeam(context.Background(), s.sdb, "o1", shared.UUID, TeamUpdate{Name: &name})
+	s.Assert().Equal(sbd.NewValidationError("shared team cannot be modified"), err)
+}
+
+func (s *Suite) Test_ArchiveTeam() {
+	_, err := CreateOrganization(context.Background(), s.sdb, "o1")
+	s.Require().NoError(err)
+
+	m := s.createMatch("o1")
+
+	_, err = ArchiveTeam(context.Background(), s.sdb, "o1", m.HomeTeamUUID)
+	s.Assert().Equal(sbd.NewValidationError("team has active matches"), err)
+
+	_, _, err = FinishMatch(context.Background(), s.sdb, "o1", m.UUID, MatchFinishRequest{})
+	s.Require().NoError(err)
+
+	t, err := ArchiveTeam(context.Background(), s.sdb, "o1", m.HomeTeamUUID)
+	s.Require().NoError(err)
+	s.Assert().True(t.ArchivedAt.Valid)
+
+	tt, err := SelectTeams(context.Background(), s.sdb, TeamFilter{
+		OrganizationID: "o1",
+	})
+	s.Require().NoError(err)
+	s.Assert().Len(tt, 1)
+
+	tt, err = SelectTeams(context.Background(), s.sdb, TeamFilter{
+		OrganizationID:  "o1",
+		IncludeArchived: true,
+	})
+	s.Require().NoError(err)
+	s.Assert().Len(tt, 2)
+
+	_, err = ArchiveTeam(context.Background(), s.sdb, "o1", m.HomeTeamUUID)
+	s.Assert().Equal(sbd.NewNotFoundError("team"), err)
+}
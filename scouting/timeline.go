@@ -0,0 +1,137 @@
+package scouting
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/sportsbydata/backend/sbd"
+)
+
+// TimelineEvent is a possession together with the slice of the game the
+// scout who recorded it was responsible for.
+type TimelineEvent struct {
+	Possession   Possession
+	ScoutMode    Mode
+	ScoutSubmode Submode
+}
+
+// mergeTimeline combines possessions of all match scouts into a single
+// timeline ordered by game time. A possession without game time takes the
+// game time of the preceding possession of the same scout, so it keeps its
+// place in that scout's sequence.
+func mergeTimeline(mss []MatchScout, pp []Possession) []TimelineEvent {
+	scouts := make(map[string]MatchScout, len(mss))
+
+	for _, ms := range mss {
+		scouts[ms.AccountID] = ms
+	}
+
+	type timed struct {
+		event    TimelineEvent
+		gameTime uint
+	}
+
+	tt := make([]timed, 0, len(pp))
+
+	for _, p := range pp {
+		ms, ok := scouts[p.AccountID]
+		if !ok {
+			continue
+		}
+
+		tt = append(tt, timed{
+			event: TimelineEvent{
+				Possession:   p,
+				ScoutMode:    ms.Mode,
+				ScoutSubmode: ms.Submode,
+			},
+		})
+	}
+
+	slices.SortFunc(tt, func(a, b timed) int {
+		return cmp.Or(
+			cmp.Compare(a.event.Possession.AccountID, b.event.Possession.AccountID),
+			cmp.Compare(a.event.Possession.Sequence, b.event.Possession.Sequence),
+		)
+	})
+
+	var (
+		account string
+		last    uint
+	)
+
+	for i := range tt {
+		p := tt[i].event.Possession
+
+		if p.AccountID != account {
+			account, last = p.AccountID, 0
+		}
+
+		if p.GameTime.Valid {
+			last = p.GameTime.V
+		}
+
+		tt[i].gameTime = last
+	}
+
+	slices.SortStableFunc(tt, func(a, b timed) int {
+		return cmp.Compare(a.gameTime, b.gameTime)
+	})
+
+	ee := make([]TimelineEvent, len(tt))
+
+	for i, t := range tt {
+		ee[i] = t.event
+	}
+
+	return ee
+}
+
+func MatchTimeline(ctx context.Context, sdb *sqlx.DB, oid string, matchUUID uuid.UUID) ([]TimelineEvent, error) {
+	logger := slog.With(
+		slog.String("organization_id", oid),
+		slog.String("match_uuid", matchUUID.String()),
+	)
+
+	mm, err := SelectMatches(ctx, sdb, MatchFilter{
+		UUID:           matchUUID,
+		AnyState:       true,
+		OrganizationID: oid,
+	}, false)
+	switch {
+	case err == nil && len(mm) > 0:
+		// OK.
+	case err == nil && len(mm) == 0:
+		return nil, sbd.NewNotFoundError("match")
+	default:
+		logger.Error("selecting matches", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	mss, err := SelectMatchScouts(ctx, sdb, MatchScoutFilter{
+		MatchUUID:           &matchUUID,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting match scouts", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	pp, err := SelectPossessions(ctx, sdb, PossessionFilter{
+		MatchUUID:           &matchUUID,
+		MatchOrganizationID: &oid,
+	})
+	if err != nil {
+		logger.Error("selecting possessions", slog.Any("error", err))
+
+		return nil, errInternal
+	}
+
+	return mergeTimeline(mss, pp), nil
+}
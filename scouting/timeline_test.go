@@ -0,0 +1,86 @@
+package scouting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeTimeline(t *testing.T) {
+	t.Parallel()
+
+	mss := []MatchScout{
+		{AccountID: "a", Mode: ModeAttack, Submode: SubmodeAnyRules},
+		{AccountID: "d", Mode: ModeDefence, Submode: SubmodePlays},
+	}
+
+	tnow := time.Now()
+
+	type event struct {
+		AccountID string
+		Sequence  uint
+		Mode      Mode
+		Submode   Submode
+	}
+
+	flatten := func(ee []TimelineEvent) []event {
+		var out []event
+
+		for _, e := range ee {
+			out = append(out, event{e.Possession.AccountID, e.Possession.Sequence, e.ScoutMode, e.ScoutSubmode})
+		}
+
+		return out
+	}
+
+	t.Run("by game time", func(t *testing.T) {
+		pp := []Possession{
+			{AccountID: "a", Sequence: 1, GameTime: null.NewValue(uint(10), true), CreatedAt: tnow.Add(time.Minute)},
+			{AccountID: "a", Sequence: 2, GameTime: null.NewValue(uint(60), true), CreatedAt: tnow},
+			{AccountID: "d", Sequence: 1, GameTime: null.NewValue(uint(30), true), CreatedAt: tnow},
+			{AccountID: "unknown", Sequence: 1, GameTime: null.NewValue(uint(5), true), CreatedAt: tnow},
+		}
+
+		assert.Equal(t, []event{
+			{"a", 1, ModeAttack, SubmodeAnyRules},
+			{"d", 1, ModeDefence, SubmodePlays},
+			{"a", 2, ModeAttack, SubmodeAnyRules},
+		}, flatten(mergeTimeline(mss, pp)))
+	})
+
+	t.Run("by sequence when game time is missing", func(t *testing.T) {
+		pp := []Possession{
+			{AccountID: "a", Sequence: 1, GameTime: null.NewValue(uint(10), true), CreatedAt: tnow.Add(time.Minute)},
+			{AccountID: "a", Sequence: 2, CreatedAt: tnow.Add(time.Minute)},
+			{AccountID: "a", Sequence: 3, GameTime: null.NewValue(uint(50), true), CreatedAt: tnow.Add(time.Minute)},
+			{AccountID: "d", Sequence: 2, GameTime: null.NewValue(uint(40), true), CreatedAt: tnow},
+			{AccountID: "d", Sequence: 1, CreatedAt: tnow},
+			{AccountID: "d", Sequence: 3, CreatedAt: tnow},
+		}
+
+		assert.Equal(t, []event{
+			{"d", 1, ModeDefence, SubmodePlays},
+			{"a", 1, ModeAttack, SubmodeAnyRules},
+			{"a", 2, ModeAttack, SubmodeAnyRules},
+			{"d", 2, ModeDefence, SubmodePlays},
+			{"d", 3, ModeDefence, SubmodePlays},
+			{"a", 3, ModeAttack, SubmodeAnyRules},
+		}, flatten(mergeTimeline(mss, pp)))
+	})
+
+	t.Run("by sequence without game time", func(t *testing.T) {
+		pp := []Possession{
+			{AccountID: "d", Sequence: 2, CreatedAt: tnow},
+			{AccountID: "a", Sequence: 1, CreatedAt: tnow.Add(time.Minute)},
+			{AccountID: "d", Sequence: 1, CreatedAt: tnow},
+		}
+
+		assert.Equal(t, []event{
+			{"a", 1, ModeAttack, SubmodeAnyRules},
+			{"d", 1, ModeDefence, SubmodePlays},
+			{"d", 2, ModeDefence, SubmodePlays},
+		}, flatten(mergeTimeline(mss, pp)))
+	})
+}
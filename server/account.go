@@ -61,8 +61,35 @@ func (s *Server) getAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var qr struct {
+		Cursor string `schema:"cursor"`
+		Limit  uint64 `schema:"limit"`
+	}
+
+	if err := s.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	after, err := decodeCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
 	f := scouting.AccountFilter{
 		OrganizationID: claims.ActiveOrganizationID,
+		After:          after,
+		Limit:          fetchLimit(limit),
 	}
 
 	aa, err := scouting.SelectAccounts(r.Context(), s.sdb, f)
@@ -72,13 +99,17 @@ func (s *Server) getAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	aa, cursor := nextPage(aa, limit, func(a scouting.Account) string {
+		return a.ID
+	})
+
 	enc := make([]account, len(aa))
 
 	for i, a := range aa {
 		enc[i] = newAccount(a)
 	}
 
-	JSON(w, http.StatusOK, enc)
+	JSON(w, http.StatusOK, page(enc, limit, cursor))
 }
 
 func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type actionStats struct {
+	ActionID            string  `json:"action_id"`
+	ActionOptionID      *string `json:"action_option_id,omitempty"`
+	Possessions         uint    `json:"possessions"`
+	Points              uint    `json:"points"`
+	Turnovers           uint    `json:"turnovers"`
+	PointsPerPossession float64 `json:"points_per_possession"`
+	Frequency           float64 `json:"frequency"`
+	TurnoverRate        float64 `json:"turnover_rate"`
+}
+
+func newActionStats(ss []scouting.ActionStats) []actionStats {
+	enc := make([]actionStats, len(ss))
+
+	for i, st := range ss {
+		enc[i] = actionStats{
+			ActionID:            st.ActionID,
+			Possessions:         st.Possessions,
+			Points:              st.Points,
+			Turnovers:           st.Turnovers,
+			PointsPerPossession: st.PointsPerPossession,
+			Frequency:           st.Frequency,
+			TurnoverRate:        st.TurnoverRate,
+		}
+
+		if st.ActionOptionID.Valid {
+			enc[i].ActionOptionID = &st.ActionOptionID.V
+		}
+	}
+
+	return enc
+}
+
+type teamActionStats struct {
+	TeamUUID uuid.UUID     `json:"team_uuid"`
+	Actions  []actionStats `json:"actions"`
+}
+
+type actionAnalytics struct {
+	League []actionStats     `json:"league"`
+	Teams  []teamActionStats `json:"teams"`
+}
+
+func newActionAnalytics(aa scouting.ActionAnalytics) actionAnalytics {
+	enc := actionAnalytics{
+		League: newActionStats(aa.League),
+		Teams:  make([]teamActionStats, 0, len(aa.Teams)),
+	}
+
+	for tuuid, ss := range aa.Teams {
+		enc.Teams = append(enc.Teams, teamActionStats{
+			TeamUUID: tuuid,
+			Actions:  newActionStats(ss),
+		})
+	}
+
+	sort.Slice(enc.Teams, func(i, j int) bool {
+		return enc.Teams[i].TeamUUID.String() < enc.Teams[j].TeamUUID.String()
+	})
+
+	return enc
+}
+
+func (rt *Server) getLeagueActionAnalytics(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	leagueUUID, err := uuid.FromString(r.PathValue("leagueID"))
+	if err != nil {
+		BadRequest(w, "invalid league identifier format")
+
+		return
+	}
+
+	var qr struct {
+		SeasonUUID uuid.UUID `schema:"season_uuid"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	aa, err := scouting.LeagueActionAnalytics(r.Context(), rt.sdb, claims.ActiveOrganizationID, leagueUUID, qr.SeasonUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newActionAnalytics(aa))
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+func (rt *Server) assignMatchScout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	var na scouting.NewMatchScoutAssignment
+
+	if err := json.NewDecoder(r.Body).Decode(&na); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	ms, err := scouting.AssignMatchScout(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, matchUUID, na)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusCreated, newMatchScout(ms))
+}
+
+func (rt *Server) acceptMatchScoutAssignment(w http.ResponseWriter, r *http.Request) {
+	rt.respondMatchScoutAssignment(w, r, true)
+}
+
+func (rt *Server) declineMatchScoutAssignment(w http.ResponseWriter, r *http.Request) {
+	rt.respondMatchScoutAssignment(w, r, false)
+}
+
+func (rt *Server) respondMatchScoutAssignment(w http.ResponseWriter, r *http.Request, accept bool) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	ms, err := scouting.RespondMatchScoutAssignment(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, matchUUID, accept)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newMatchScout(ms))
+}
+
+func (rt *Server) proposeAssignments(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		From time.Time `schema:"from"`
+		To   time.Time `schema:"to"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	pp, err := scouting.ProposeAssignments(r.Context(), rt.sdb, claims.ActiveOrganizationID, qr.From, qr.To)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, pp)
+}
+
+func (rt *Server) commitAssignments(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var req struct {
+		Assignments []scouting.ProposedAssignment `json:"assignments"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	mss, err := scouting.CommitAssignments(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, req.Assignments)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]matchScout, len(mss))
+
+	for i, ms := range mss {
+		enc[i] = newMatchScout(ms)
+	}
+
+	JSON(w, http.StatusCreated, enc)
+}
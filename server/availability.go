@@ -0,0 +1,146 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type availability struct {
+	UUID      uuid.UUID `json:"uuid"`
+	AccountID string    `json:"account_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newAvailability(a scouting.Availability) availability {
+	return availability{
+		UUID:      a.UUID,
+		AccountID: a.AccountID,
+		StartsAt:  a.StartsAt,
+		EndsAt:    a.EndsAt,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func (rt *Server) createAvailability(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var na scouting.NewAvailability
+
+	if err := json.NewDecoder(r.Body).Decode(&na); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	a, err := scouting.CreateAvailability(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, na)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusCreated, newAvailability(a))
+}
+
+func (rt *Server) getAvailabilities(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		AccountID string    `schema:"account_id"`
+		From      time.Time `schema:"from"`
+		To        time.Time `schema:"to"`
+		Cursor    string    `schema:"cursor"`
+		Limit     uint64    `schema:"limit"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	after, err := decodeUUIDCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	aa, err := scouting.SelectAvailabilities(r.Context(), rt.sdb, scouting.AvailabilityFilter{
+		OrganizationID: claims.ActiveOrganizationID,
+		AccountID:      qr.AccountID,
+		From:           qr.From,
+		To:             qr.To,
+		After:          after,
+		Limit:          fetchLimit(limit),
+	})
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	aa, cursor := nextPage(aa, limit, func(a scouting.Availability) string {
+		return a.UUID.String()
+	})
+
+	enc := make([]availability, len(aa))
+
+	for i, a := range aa {
+		enc[i] = newAvailability(a)
+	}
+
+	JSON(w, http.StatusOK, page(enc, limit, cursor))
+}
+
+func (rt *Server) deleteAvailability(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	availabilityUUID, err := uuid.FromString(r.PathValue("availabilityID"))
+	if err != nil {
+		BadRequest(w, "invalid availability identifier format")
+
+		return
+	}
+
+	if err := scouting.DeleteAvailability(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, availabilityUUID); err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
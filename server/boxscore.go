@@ -0,0 +1,66 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type boxScore struct {
+	Attack  boxScoreLine `json:"attack"`
+	Defence boxScoreLine `json:"defence"`
+}
+
+type boxScoreLine struct {
+	Possessions       uint            `json:"possessions"`
+	Points            uint            `json:"points"`
+	Shots             uint            `json:"shots"`
+	FreeThrowAttempts uint            `json:"free_throw_attempts"`
+	StatisticTags     map[string]uint `json:"statistic_tags"`
+}
+
+func newBoxScore(bs scouting.BoxScore) boxScore {
+	return boxScore{
+		Attack:  newBoxScoreLine(bs.Attack),
+		Defence: newBoxScoreLine(bs.Defence),
+	}
+}
+
+func newBoxScoreLine(bl scouting.BoxScoreLine) boxScoreLine {
+	return boxScoreLine{
+		Possessions:       bl.Possessions,
+		Points:            bl.Points,
+		Shots:             bl.Shots,
+		FreeThrowAttempts: bl.FreeThrowAttempts,
+		StatisticTags:     bl.StatisticTags,
+	}
+}
+
+func (rt *Server) getMatchBoxScore(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	bs, err := scouting.MatchBoxScore(r.Context(), rt.sdb, claims.ActiveOrganizationID, matchUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newBoxScore(bs))
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/organizationmembership"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+const (
+	calendarTimeFormat = "20060102T150405Z"
+
+	// calendarMatchDuration is the event length, matches do not store an
+	// expected end time.
+	calendarMatchDuration = 2 * time.Hour
+
+	calendarLineLimit = 75
+)
+
+type calendarToken struct {
+	Token      string `json:"token"`
+	MatchesURL string `json:"matches_url"`
+	ScoutURL   string `json:"scout_url"`
+}
+
+func newCalendarToken(token string) calendarToken {
+	return calendarToken{
+		Token:      token,
+		MatchesURL: fmt.Sprintf("/v1/calendar/%s/matches.ics", token),
+		ScoutURL:   fmt.Sprintf("/v1/calendar/%s/scout.ics", token),
+	}
+}
+
+// icsEscape escapes text values as defined in RFC 5545 section 3.3.11.
+func icsEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeICSLine writes a content line, folding it at 75 octets without
+// splitting UTF-8 sequences.
+func writeICSLine(w io.Writer, line string) {
+	limit := calendarLineLimit
+
+	for len(line) > limit {
+		i := limit
+
+		for i > 0 && line[i]&0xC0 == 0x80 {
+			i--
+		}
+
+		io.WriteString(w, line[:i]+"\r\n ")
+		line = line[i:]
+
+		// Continuation lines start with a space.
+		limit = calendarLineLimit - 1
+	}
+
+	io.WriteString(w, line+"\r\n")
+}
+
+func teamName(t scouting.Team) string {
+	if t.Name == "" {
+		return "TBD"
+	}
+
+	return t.Name
+}
+
+func scoutName(a scouting.Account) string {
+	name := strings.TrimSpace(a.FirstName + " " + a.LastName)
+	if name == "" {
+		return a.ID
+	}
+
+	return name
+}
+
+func writeICS(w io.Writer, name string, ee []scouting.CalendarEvent, now time.Time) {
+	writeICSLine(w, "BEGIN:VCALENDAR")
+	writeICSLine(w, "VERSION:2.0")
+	writeICSLine(w, "PRODID:-//Sports by Data//Scouting//EN")
+	writeICSLine(w, "CALSCALE:GREGORIAN")
+	writeICSLine(w, "METHOD:PUBLISH")
+	writeICSLine(w, "X-WR-CALNAME:"+icsEscape(name))
+
+	for _, e := range ee {
+		home, away := teamName(e.HomeTeam), teamName(e.AwayTeam)
+
+		desc := []string{
+			fmt.Sprintf("Home: %s", home),
+			fmt.Sprintf("Away: %s", away),
+		}
+
+		for _, s := range e.Scouts {
+			desc = append(desc, fmt.Sprintf("Scout: %s (%s, %s)", scoutName(s.Account), s.Mode, s.Submode))
+		}
+
+		writeICSLine(w, "BEGIN:VEVENT")
+		writeICSLine(w, fmt.Sprintf("UID:%s@sportsbydata", e.Match.UUID))
+		writeICSLine(w, "DTSTAMP:"+now.UTC().Format(calendarTimeFormat))
+		writeICSLine(w, "DTSTART:"+e.Match.StartsAt.UTC().Format(calendarTimeFormat))
+		writeICSLine(w, "DTEND:"+e.Match.StartsAt.Add(calendarMatchDuration).UTC().Format(calendarTimeFormat))
+		writeICSLine(w, "SUMMARY:"+icsEscape(home+" vs "+away))
+		writeICSLine(w, "DESCRIPTION:"+icsEscape(strings.Join(desc, "\n")))
+		writeICSLine(w, "END:VEVENT")
+	}
+
+	writeICSLine(w, "END:VCALENDAR")
+}
+
+func (s *Server) createCalendarToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	token, err := scouting.CreateCalendarToken(r.Context(), s.sdb, claims.ActiveOrganizationID, claims.Subject)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusCreated, newCalendarToken(token))
+}
+
+func (s *Server) deleteCalendarToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	if err := scouting.DeleteCalendarToken(r.Context(), s.sdb, claims.ActiveOrganizationID, claims.Subject); err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getMatchesCalendar(w http.ResponseWriter, r *http.Request) {
+	s.serveCalendar(w, r, "Matches", false)
+}
+
+func (s *Server) getScoutCalendar(w http.ResponseWriter, r *http.Request) {
+	s.serveCalendar(w, r, "My scouting", true)
+}
+
+// serveCalendar authenticates the feed by the token in its path, calendar
+// apps cannot send session tokens.
+func (s *Server) serveCalendar(w http.ResponseWriter, r *http.Request, name string, scout bool) {
+	ct, err := scouting.SelectCalendarToken(r.Context(), s.sdb, r.PathValue("token"))
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	// Tokens outlive sessions, so the account may have left the organization
+	// since the token was issued.
+	ml, err := organizationmembership.List(r.Context(), &organizationmembership.ListParams{
+		OrganizationID: ct.OrganizationID,
+		UserIDs:        []string{ct.AccountID},
+	})
+	if err != nil {
+		slog.Error("listing organization memberships", slog.Any("error", err))
+		Internal(w)
+
+		return
+	}
+
+	if ml.TotalCount == 0 {
+		if err = scouting.DeleteCalendarToken(r.Context(), s.sdb, ct.OrganizationID, ct.AccountID); err != nil {
+			HandleError(w, err)
+
+			return
+		}
+
+		HandleError(w, sbd.NewNotFoundError("calendar"))
+
+		return
+	}
+
+	var qr struct {
+		LeagueUUID uuid.UUID `schema:"league_uuid"`
+	}
+
+	if err := s.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	f := scouting.CalendarFilter{
+		LeagueUUID: qr.LeagueUUID,
+	}
+
+	if scout {
+		f.AccountID = ct.AccountID
+	}
+
+	ee, err := scouting.SelectCalendarEvents(r.Context(), s.sdb, ct.OrganizationID, f)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	writeICS(w, name, ee, time.Now())
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_icsEscape(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `a\\b\;c\,d\ne`, icsEscape("a\\b;c,d\ne"))
+}
+
+func Test_writeICSLine(t *testing.T) {
+	t.Parallel()
+
+	var sb strings.Builder
+
+	writeICSLine(&sb, "SUMMARY:"+strings.Repeat("ä", 40))
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n")
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("ä", 33), lines[0])
+	assert.Equal(t, " "+strings.Repeat("ä", 7), lines[1])
+}
+
+func Test_writeICS(t *testing.T) {
+	t.Parallel()
+
+	starts := time.Date(2024, time.October, 1, 18, 0, 0, 0, time.UTC)
+
+	var sb strings.Builder
+
+	writeICS(&sb, "Matches", []scouting.CalendarEvent{
+		{
+			Match: scouting.Match{
+				UUID:     uuid.UUID{15: 1},
+				StartsAt: starts,
+			},
+			HomeTeam: scouting.Team{Name: "Lions"},
+			AwayTeam: scouting.Team{Name: "Tigers, B"},
+			Scouts: []scouting.CalendarScout{
+				{
+					MatchScout: scouting.MatchScout{
+						Mode:    scouting.ModeAttack,
+						Submode: scouting.SubmodeAllRules,
+					},
+					Account: scouting.Account{FirstName: "John", LastName: "Doe"},
+				},
+			},
+		},
+	}, starts.Add(-time.Hour))
+
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Sports by Data//Scouting//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Matches",
+		"BEGIN:VEVENT",
+		"UID:00000000-0000-0000-0000-000000000001@sportsbydata",
+		"DTSTAMP:20241001T170000Z",
+		"DTSTART:20241001T180000Z",
+		"DTEND:20241001T200000Z",
+		`SUMMARY:Lions vs Tigers\, B`,
+		`DESCRIPTION:Home: Lions\nAway: Tigers\, B\nScout: John Doe (attack\, all_ru`,
+		" les)",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), sb.String())
+}
@@ -0,0 +1,264 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+const maxConfigSize = 1 << 20
+
+type scoutingConfigVersion struct {
+	Version        uint           `json:"version"`
+	ScoutingConfig scoutingConfig `json:"scouting_config"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+func newScoutingConfigVersion(v scouting.ScoutingConfigVersion) scoutingConfigVersion {
+	return scoutingConfigVersion{
+		Version:        v.Version,
+		ScoutingConfig: newScoutingConfig(v.ScoutingConfig),
+		CreatedAt:      v.CreatedAt,
+	}
+}
+
+type configChange struct {
+	Entity string                    `json:"entity"`
+	ID     string                    `json:"id"`
+	Kind   scouting.ConfigChangeKind `json:"kind"`
+}
+
+type scoutingConfigDiff struct {
+	From    uint           `json:"from"`
+	To      uint           `json:"to"`
+	Changes []configChange `json:"changes"`
+}
+
+func newConfigChanges(cc []scouting.ConfigChange) []configChange {
+	enc := make([]configChange, len(cc))
+
+	for i, c := range cc {
+		enc[i] = configChange{
+			Entity: c.Entity,
+			ID:     c.ID,
+			Kind:   c.Kind,
+		}
+	}
+
+	return enc
+}
+
+func newScoutingConfigDiff(d scouting.ScoutingConfigDiff) scoutingConfigDiff {
+	return scoutingConfigDiff{
+		From:    d.From,
+		To:      d.To,
+		Changes: newConfigChanges(d.Changes),
+	}
+}
+
+func (s *Server) getScoutingConfigVersions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		Cursor string `schema:"cursor"`
+		Limit  uint64 `schema:"limit"`
+	}
+
+	if err := s.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	before, err := decodeUintCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	vv, err := scouting.SelectScoutingConfigVersions(r.Context(), s.sdb, scouting.ScoutingConfigVersionFilter{
+		OrganizationID: claims.ActiveOrganizationID,
+		Before:         before,
+		Limit:          fetchLimit(limit),
+	})
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	vv, cursor := nextPage(vv, limit, func(v scouting.ScoutingConfigVersion) string {
+		return strconv.FormatUint(uint64(v.Version), 10)
+	})
+
+	enc := make([]scoutingConfigVersion, len(vv))
+
+	for i, v := range vv {
+		enc[i] = newScoutingConfigVersion(v)
+	}
+
+	JSON(w, http.StatusOK, page(enc, limit, cursor))
+}
+
+func (s *Server) diffScoutingConfigVersions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		From uint `schema:"from,required"`
+		To   uint `schema:"to,required"`
+	}
+
+	if err := s.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	d, err := scouting.DiffScoutingConfigVersions(r.Context(), s.sdb, claims.ActiveOrganizationID, qr.From, qr.To)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newScoutingConfigDiff(d))
+}
+
+type configProblem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type scoutingConfigImport struct {
+	Problems []configProblem `json:"problems"`
+	Changes  []configChange  `json:"changes"`
+	Applied  bool            `json:"applied"`
+	Version  uint            `json:"version"`
+}
+
+func newScoutingConfigImport(ci scouting.ScoutingConfigImport) scoutingConfigImport {
+	pp := make([]configProblem, len(ci.Problems))
+
+	for i, p := range ci.Problems {
+		pp[i] = configProblem{
+			Path:    p.Path,
+			Message: p.Message,
+		}
+	}
+
+	return scoutingConfigImport{
+		Problems: pp,
+		Changes:  newConfigChanges(ci.Changes),
+		Applied:  ci.Applied,
+		Version:  ci.Version,
+	}
+}
+
+var configContentTypes = map[scouting.ConfigFormat]string{
+	scouting.ConfigFormatYAML: "application/yaml",
+	scouting.ConfigFormatJSON: "application/json",
+}
+
+func (s *Server) exportScoutingConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		Format scouting.ConfigFormat `schema:"format"`
+	}
+
+	if err := s.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	if qr.Format == "" {
+		qr.Format = scouting.ConfigFormatYAML
+	}
+
+	data, err := scouting.ExportScoutingConfig(r.Context(), s.sdb, claims.ActiveOrganizationID, qr.Format)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", configContentTypes[qr.Format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="scouting_config.%s"`, qr.Format))
+	w.WriteHeader(http.StatusOK)
+
+	w.Write(data)
+}
+
+func (s *Server) importScoutingConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		Format scouting.ConfigFormat `schema:"format"`
+		DryRun bool                  `schema:"dry_run"`
+	}
+
+	if err := s.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	if qr.Format == "" {
+		qr.Format = scouting.ConfigFormatYAML
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConfigSize))
+	if err != nil {
+		BadRequest(w, "invalid body")
+
+		return
+	}
+
+	ci, err := scouting.ImportScoutingConfig(r.Context(), s.sdb, claims.ActiveOrganizationID, data, qr.Format, qr.DryRun)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newScoutingConfigImport(ci))
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type scoutSlot struct {
+	Mode    scouting.Mode    `json:"mode"`
+	Submode scouting.Submode `json:"submode"`
+}
+
+type matchCoverage struct {
+	Match        match       `json:"match"`
+	Claimable    []scoutSlot `json:"claimable"`
+	AttackRules  bool        `json:"attack_rules"`
+	AttackPlays  bool        `json:"attack_plays"`
+	DefenceRules bool        `json:"defence_rules"`
+	DefencePlays bool        `json:"defence_plays"`
+	Full         bool        `json:"full"`
+}
+
+func newMatchCoverage(mc scouting.MatchCoverage) matchCoverage {
+	enc := matchCoverage{
+		Match:        newMatch(mc.Match),
+		Claimable:    make([]scoutSlot, len(mc.Claimable)),
+		AttackRules:  mc.AttackRules,
+		AttackPlays:  mc.AttackPlays,
+		DefenceRules: mc.DefenceRules,
+		DefencePlays: mc.DefencePlays,
+		Full:         mc.Full,
+	}
+
+	for i, ss := range mc.Claimable {
+		enc.Claimable[i] = scoutSlot(ss)
+	}
+
+	return enc
+}
+
+func (rt *Server) getMatchCoverage(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		LeagueUUID uuid.UUID `schema:"league_uuid"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	mcc, err := scouting.SelectCoverage(r.Context(), rt.sdb, claims.ActiveOrganizationID, scouting.CoverageFilter{
+		LeagueUUID: qr.LeagueUUID,
+	})
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]matchCoverage, len(mcc))
+
+	for i, mc := range mcc {
+		enc[i] = newMatchCoverage(mc)
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+func (s *Server) getLayouts(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	filter := scouting.OrganizationFilter{
+		IDs: []string{claims.ActiveOrganizationID},
+	}
+
+	oo, err := scouting.SelectOrganizations(r.Context(), s.sdb, filter)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	if len(oo) == 0 {
+		NotFound(w, "organization not found")
+
+		return
+	}
+
+	ll := make([]layout, len(oo[0].ScoutingConfig.Layouts))
+
+	for i, l := range oo[0].ScoutingConfig.Layouts {
+		ll[i] = newLayout(l)
+	}
+
+	JSON(w, http.StatusOK, ll)
+}
+
+func (s *Server) createLayout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var in scouting.Layout
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	l, err := scouting.CreateLayout(r.Context(), s.sdb, claims.ActiveOrganizationID, in)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusCreated, newLayout(l))
+}
+
+func (s *Server) updateLayout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var in scouting.Layout
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	l, err := scouting.UpdateLayout(r.Context(), s.sdb, claims.ActiveOrganizationID, r.PathValue("layoutName"), in)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newLayout(l))
+}
+
+func (s *Server) deleteLayout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	err := scouting.DeleteLayout(r.Context(), s.sdb, claims.ActiveOrganizationID, r.PathValue("layoutName"))
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
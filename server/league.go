@@ -2,31 +2,26 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/sbd"
 	"github.com/sportsbydata/backend/scouting"
 )
 
 type league struct {
-	UUID  uuid.UUID `json:"uuid"`
-	Name  string    `json:"name"`
-	Teams []team    `json:"teams,omitempty"`
+	UUID   uuid.UUID `json:"uuid"`
+	Name   string    `json:"name"`
+	Season *season   `json:"season,omitempty"`
 }
 
-func newLeague(l scouting.League, teams []scouting.Team) league {
-	tt := make([]team, len(teams))
-
-	for i, t := range teams {
-		tt[i] = newTeam(t)
-	}
-
+func newLeague(l scouting.League) league {
 	return league{
-		UUID:  l.UUID,
-		Name:  l.Name,
-		Teams: tt,
+		UUID: l.UUID,
+		Name: l.Name,
 	}
 }
 
@@ -47,7 +42,7 @@ func (rt *Server) createLeague(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	l, err := scouting.CreateLeague(r.Context(), nl, rt.sdb)
+	l, s, err := scouting.CreateLeague(r.Context(), rt.sdb, claims.ActiveOrganizationID, nl)
 	if err != nil {
 		HandleError(w, err)
 
@@ -55,8 +50,9 @@ func (rt *Server) createLeague(w http.ResponseWriter, r *http.Request) {
 	}
 
 	tt, err := scouting.SelectTeams(r.Context(), rt.sdb, scouting.TeamFilter{
-		LeagueUUID:     l.UUID,
-		OrganizationID: claims.ActiveOrganizationID,
+		SeasonUUID:      s.UUID,
+		OrganizationID:  claims.ActiveOrganizationID,
+		IncludeArchived: true,
 	})
 	if err != nil {
 		HandleError(w, err)
@@ -64,7 +60,11 @@ func (rt *Server) createLeague(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSON(w, http.StatusCreated, newLeague(l, tt))
+	enc := newLeague(l)
+	es := newSeason(s, tt)
+	enc.Season = &es
+
+	JSON(w, http.StatusCreated, enc)
 }
 
 func (rt *Server) updateOrganizationLeagues(w http.ResponseWriter, r *http.Request) {
@@ -112,6 +112,9 @@ func (rt *Server) getLeagues(w http.ResponseWriter, r *http.Request) {
 
 	var qr struct {
 		LeagueUUID uuid.UUID `schema:"league_uuid"`
+		SeasonUUID uuid.UUID `schema:"season_uuid"`
+		Cursor     string    `schema:"cursor"`
+		Limit      uint64    `schema:"limit"`
 	}
 
 	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
@@ -120,24 +123,62 @@ func (rt *Server) getLeagues(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	after, err := decodeUUIDCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
 	f := scouting.LeagueFilter{
 		LeagueUUID:     qr.LeagueUUID,
 		OrganizationID: claims.ActiveOrganizationID,
+		After:          after,
+		Limit:          fetchLimit(limit),
 	}
 
-	ll, err := scouting.SelectLeagues(r.Context(), rt.sdb, f)
+	ll, err := scouting.SelectLeagues(r.Context(), rt.sdb, f, false)
 	if err != nil {
 		HandleError(w, err)
 
 		return
 	}
 
-	leagueTeams := make(map[uuid.UUID][]scouting.Team)
+	ll, cursor := nextPage(ll, limit, func(l scouting.League) string {
+		return l.UUID.String()
+	})
+
+	var nfe *sbd.NotFoundError
+
+	mapped := make([]league, len(ll))
+
+	for i, l := range ll {
+		mapped[i] = newLeague(l)
+
+		// Leagues show teams of the requested or current season.
+		s, err := scouting.ResolveSeason(r.Context(), rt.sdb, claims.ActiveOrganizationID, l.UUID, qr.SeasonUUID)
+		switch {
+		case err == nil:
+			// OK.
+		case errors.As(err, &nfe):
+			continue
+		default:
+			HandleError(w, err)
+
+			return
+		}
 
-	for _, l := range ll {
 		tt, err := scouting.SelectTeams(r.Context(), rt.sdb, scouting.TeamFilter{
-			OrganizationID: claims.ActiveOrganizationID,
-			LeagueUUID:     l.UUID,
+			OrganizationID:  claims.ActiveOrganizationID,
+			SeasonUUID:      s.UUID,
+			IncludeArchived: true,
 		})
 		if err != nil {
 			HandleError(w, err)
@@ -145,14 +186,9 @@ func (rt *Server) getLeagues(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		leagueTeams[l.UUID] = tt
-	}
-
-	mapped := make([]league, len(ll))
-
-	for i, l := range ll {
-		mapped[i] = newLeague(l, leagueTeams[l.UUID])
+		es := newSeason(s, tt)
+		mapped[i].Season = &es
 	}
 
-	JSON(w, http.StatusOK, mapped)
+	JSON(w, http.StatusOK, page(mapped, limit, cursor))
 }
@@ -12,25 +12,62 @@ import (
 )
 
 type match struct {
-	ID           string     `json:"id"`
-	LeagueUUID   uuid.UUID  `json:"league_uuid"`
-	AwayTeamUUID uuid.UUID  `json:"away_team_uuid"`
-	HomeTeamUUID uuid.UUID  `json:"home_team_uuid"`
-	CreatedBy    string     `json:"created_by"`
-	HomeScore    *uint      `json:"home_score,omitempty"`
-	AwayScore    *uint      `json:"away_score,omitempty"`
-	StartsAt     time.Time  `json:"starts_at"`
-	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	ID              string               `json:"id"`
+	LeagueUUID      uuid.UUID            `json:"league_uuid"`
+	SeasonUUID      uuid.UUID            `json:"season_uuid"`
+	AwayTeamUUID    uuid.UUID            `json:"away_team_uuid"`
+	HomeTeamUUID    uuid.UUID            `json:"home_team_uuid"`
+	ScoutedTeamUUID uuid.UUID            `json:"scouted_team_uuid"`
+	CreatedBy       string               `json:"created_by"`
+	HomeScore       *uint                `json:"home_score,omitempty"`
+	AwayScore       *uint                `json:"away_score,omitempty"`
+	StartsAt        time.Time            `json:"starts_at"`
+	Status          string               `json:"status"`
+	FinishedAt      *time.Time           `json:"finished_at,omitempty"`
+	Reconciliation  *matchReconciliation `json:"reconciliation,omitempty"`
+}
+
+type matchReconciliation struct {
+	ScoutedPointsFor     *uint     `json:"scouted_points_for,omitempty"`
+	ScoutedPointsAgainst *uint     `json:"scouted_points_against,omitempty"`
+	PointsFor            uint      `json:"points_for"`
+	PointsAgainst        uint      `json:"points_against"`
+	Discrepancy          uint      `json:"discrepancy"`
+	Overridden           bool      `json:"overridden"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+func newMatchReconciliation(mr scouting.MatchReconciliation) *matchReconciliation {
+	enc := &matchReconciliation{
+		PointsFor:     mr.PointsFor,
+		PointsAgainst: mr.PointsAgainst,
+		Discrepancy:   mr.Discrepancy,
+		Overridden:    mr.Overridden,
+		CreatedAt:     mr.CreatedAt,
+	}
+
+	if mr.ScoutedPointsFor.Valid {
+		enc.ScoutedPointsFor = &mr.ScoutedPointsFor.V
+	}
+
+	if mr.ScoutedPointsAgainst.Valid {
+		enc.ScoutedPointsAgainst = &mr.ScoutedPointsAgainst.V
+	}
+
+	return enc
 }
 
 func newMatch(m scouting.Match) match {
 	enc := match{
-		ID:           m.UUID.String(),
-		LeagueUUID:   m.LeagueUUID,
-		AwayTeamUUID: m.AwayTeamUUID,
-		HomeTeamUUID: m.HomeTeamUUID,
-		CreatedBy:    m.CreatedBy,
-		StartsAt:     m.StartsAt,
+		ID:              m.UUID.String(),
+		LeagueUUID:      m.LeagueUUID,
+		SeasonUUID:      m.SeasonUUID,
+		AwayTeamUUID:    m.AwayTeamUUID,
+		HomeTeamUUID:    m.HomeTeamUUID,
+		ScoutedTeamUUID: m.ScoutedTeamUUID,
+		CreatedBy:       m.CreatedBy,
+		StartsAt:        m.StartsAt,
+		Status:          string(m.Status),
 	}
 
 	if m.HomeScore.Valid {
@@ -99,7 +136,7 @@ func (rt *Server) finishMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m, err := scouting.FinishMatch(
+	m, mr, err := scouting.FinishMatch(
 		r.Context(),
 		rt.sdb,
 		claims.ActiveOrganizationID,
@@ -112,7 +149,10 @@ func (rt *Server) finishMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSON(w, http.StatusOK, newMatch(m))
+	enc := newMatch(m)
+	enc.Reconciliation = newMatchReconciliation(mr)
+
+	JSON(w, http.StatusOK, enc)
 }
 
 func (rt *Server) getFinishedMatches(w http.ResponseWriter, r *http.Request) {
@@ -124,9 +164,44 @@ func (rt *Server) getFinishedMatches(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var qr struct {
+		SeasonUUID uuid.UUID `schema:"season_uuid"`
+		Cursor     string    `schema:"cursor"`
+		Limit      uint64    `schema:"limit"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	after, err := decodeUUIDCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
 	f := scouting.MatchFilter{
 		OrganizationID: claims.ActiveOrganizationID,
 		Active:         false,
+		SeasonUUID:     qr.SeasonUUID,
+		After:          after,
+		Limit:          fetchLimit(limit),
+	}
+
+	// Finished matches default to the current season of each league, which
+	// between seasons is the one that ended last.
+	if qr.SeasonUUID.IsNil() {
+		f.CurrentSeasonAt = time.Now()
 	}
 
 	mm, err := scouting.SelectMatches(r.Context(), rt.sdb, f, false)
@@ -136,13 +211,17 @@ func (rt *Server) getFinishedMatches(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	mm, cursor := nextPage(mm, limit, func(m scouting.Match) string {
+		return m.UUID.String()
+	})
+
 	enc := make([]match, len(mm))
 
 	for i, m := range mm {
 		enc[i] = newMatch(m)
 	}
 
-	JSON(w, http.StatusOK, Paginated(enc, ""))
+	JSON(w, http.StatusOK, Paginated(enc, cursor))
 }
 
 func (rt *Server) getMatch(w http.ResponseWriter, r *http.Request) {
@@ -179,7 +258,22 @@ func (rt *Server) getMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSON(w, http.StatusOK, newMatch(mm[0]))
+	mrr, err := scouting.SelectMatchReconciliations(r.Context(), rt.sdb, scouting.MatchReconciliationFilter{
+		MatchUUIDs: []uuid.UUID{matchUUID},
+	})
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := newMatch(mm[0])
+
+	if len(mrr) > 0 {
+		enc.Reconciliation = newMatchReconciliation(mrr[0])
+	}
+
+	JSON(w, http.StatusOK, enc)
 }
 
 func (rt *Server) getActiveMatches(w http.ResponseWriter, r *http.Request) {
@@ -191,9 +285,38 @@ func (rt *Server) getActiveMatches(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var qr struct {
+		SeasonUUID uuid.UUID `schema:"season_uuid"`
+		Cursor     string    `schema:"cursor"`
+		Limit      uint64    `schema:"limit"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	after, err := decodeUUIDCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
 	f := scouting.MatchFilter{
 		OrganizationID: claims.ActiveOrganizationID,
 		Active:         true,
+		SeasonUUID:     qr.SeasonUUID,
+		After:          after,
+		Limit:          fetchLimit(limit),
 	}
 
 	mm, err := scouting.SelectMatches(r.Context(), rt.sdb, f, false)
@@ -203,27 +326,45 @@ func (rt *Server) getActiveMatches(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	mm, cursor := nextPage(mm, limit, func(m scouting.Match) string {
+		return m.UUID.String()
+	})
+
 	enc := make([]match, len(mm))
 
 	for i, m := range mm {
 		enc[i] = newMatch(m)
 	}
 
-	JSON(w, http.StatusOK, enc)
+	JSON(w, http.StatusOK, page(enc, limit, cursor))
 }
 
 type matchScout struct {
-	AccountID  string           `json:"account_id"`
-	Mode       scouting.Mode    `json:"mode"`
-	Submode    scouting.Submode `json:"submode"`
-	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+	AccountID     string           `json:"account_id"`
+	Mode          scouting.Mode    `json:"mode"`
+	Submode       scouting.Submode `json:"submode"`
+	Layout        *string          `json:"layout,omitempty"`
+	ConfigVersion uint             `json:"config_version"`
+	Status        string           `json:"status"`
+	AssignedBy    *string          `json:"assigned_by,omitempty"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
 }
 
 func newMatchScout(ms scouting.MatchScout) matchScout {
 	enc := matchScout{
-		AccountID: ms.AccountID,
-		Mode:      ms.Mode,
-		Submode:   ms.Submode,
+		AccountID:     ms.AccountID,
+		Mode:          ms.Mode,
+		Submode:       ms.Submode,
+		ConfigVersion: ms.ConfigVersion,
+		Status:        string(ms.Status),
+	}
+
+	if ms.Layout.Valid {
+		enc.Layout = &ms.Layout.V
+	}
+
+	if ms.AssignedBy.Valid {
+		enc.AssignedBy = &ms.AssignedBy.V
 	}
 
 	if ms.FinishedAt.Valid {
@@ -252,6 +393,11 @@ func (rt *Server) getMatchScouts(w http.ResponseWriter, r *http.Request) {
 	f := scouting.MatchScoutFilter{
 		MatchUUID:           &matchUUID,
 		MatchOrganizationID: &claims.ActiveOrganizationID,
+		Statuses: []scouting.MatchScoutStatus{
+			scouting.MatchScoutStatusPending,
+			scouting.MatchScoutStatusAccepted,
+			scouting.MatchScoutStatusDeclined,
+		},
 	}
 
 	mss, err := scouting.SelectMatchScouts(r.Context(), rt.sdb, f)
@@ -319,13 +465,21 @@ func (rt *Server) finishMatchScouting(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var sr scouting.ScoutReport
+
+	if err := json.NewDecoder(r.Body).Decode(&sr); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
 	_, err = scouting.SubmitScoutReport(
 		r.Context(),
 		rt.sdb,
 		claims.ActiveOrganizationID,
 		claims.Subject,
 		matchUUID,
-		scouting.ScoutReport{},
+		sr,
 	)
 	if err != nil {
 		HandleError(w, err)
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+func (s *Suite) Test_getFinishedMatchesCursor() {
+	ctx := context.Background()
+
+	_, err := scouting.CreateOrganization(ctx, s.sdb, "o1")
+	s.Require().NoError(err)
+
+	home, err := scouting.CreateTeam(ctx, s.sdb, "o1", scouting.NewTeam{Name: "home"})
+	s.Require().NoError(err)
+
+	away, err := scouting.CreateTeam(ctx, s.sdb, "o1", scouting.NewTeam{Name: "away"})
+	s.Require().NoError(err)
+
+	l, _, err := scouting.CreateLeague(ctx, s.sdb, "o1", scouting.NewLeague{
+		Name: "league",
+		Season: scouting.NewSeason{
+			Name:      "season",
+			StartsAt:  time.Now().Add(-time.Hour),
+			EndsAt:    time.Now().AddDate(1, 0, 0),
+			TeamUUIDs: []uuid.UUID{home.UUID, away.UUID},
+		},
+	})
+	s.Require().NoError(err)
+
+	err = scouting.UpdateOrganizationLeagues(ctx, s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	var ids []string
+
+	for i := range 5 {
+		m, err := scouting.CreateMatch(ctx, s.sdb, "o1", "test_scout", scouting.NewMatch{
+			LeagueUUID:   l.UUID,
+			AwayTeamUUID: away.UUID,
+			HomeTeamUUID: home.UUID,
+			StartsAt:     time.Now().Add(time.Duration(i+1) * time.Hour),
+		})
+		s.Require().NoError(err)
+
+		_, _, err = scouting.FinishMatch(ctx, s.sdb, "o1", m.UUID, scouting.MatchFinishRequest{Override: true})
+		s.Require().NoError(err)
+
+		ids = append(ids, m.UUID.String())
+	}
+
+	// An active match is not listed.
+	_, err = scouting.CreateMatch(ctx, s.sdb, "o1", "test_scout", scouting.NewMatch{
+		LeagueUUID:   l.UUID,
+		AwayTeamUUID: away.UUID,
+		HomeTeamUUID: home.UUID,
+		StartsAt:     time.Now().Add(time.Hour),
+	})
+	s.Require().NoError(err)
+
+	type page struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+		Cursor string `json:"cursor"`
+	}
+
+	get := func(q url.Values) page {
+		req := httptest.NewRequest(http.MethodGet, "/v1/matches/finished?"+q.Encode(), http.NoBody)
+		req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), &clerk.SessionClaims{
+			Claims: clerk.Claims{
+				ActiveOrganizationID: "o1",
+			},
+		}))
+
+		rec := httptest.NewRecorder()
+		s.rt.getFinishedMatches(rec, req)
+		s.Require().Equal(http.StatusOK, rec.Code)
+
+		var p page
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &p))
+
+		return p
+	}
+
+	var (
+		got   []string
+		pages int
+		q     = url.Values{"limit": {"2"}}
+	)
+
+	for {
+		p := get(q)
+		pages++
+
+		for _, m := range p.Items {
+			got = append(got, m.ID)
+		}
+
+		if p.Cursor == "" {
+			break
+		}
+
+		s.Require().Len(p.Items, 2)
+		s.Require().Less(pages, 5)
+
+		q.Set("cursor", p.Cursor)
+	}
+
+	s.Assert().Equal(3, pages)
+	s.Assert().Equal(ids, got)
+
+	// Without limit and cursor all finished matches are returned at once.
+	p := get(url.Values{})
+	s.Assert().Len(p.Items, 5)
+	s.Assert().Empty(p.Cursor)
+
+	// A cursor without a limit continues with pages of the default size.
+	p = get(url.Values{"cursor": {encodeCursor(ids[0])}})
+	s.Assert().Len(p.Items, 4)
+	s.Assert().Empty(p.Cursor)
+}
+
+func (s *Suite) Test_getActiveMatchesUnpaged() {
+	ctx := context.Background()
+
+	_, err := scouting.CreateOrganization(ctx, s.sdb, "o1")
+	s.Require().NoError(err)
+
+	home, err := scouting.CreateTeam(ctx, s.sdb, "o1", scouting.NewTeam{Name: "home"})
+	s.Require().NoError(err)
+
+	away, err := scouting.CreateTeam(ctx, s.sdb, "o1", scouting.NewTeam{Name: "away"})
+	s.Require().NoError(err)
+
+	l, _, err := scouting.CreateLeague(ctx, s.sdb, "o1", scouting.NewLeague{
+		Name: "league",
+		Season: scouting.NewSeason{
+			Name:      "season",
+			StartsAt:  time.Now().Add(-time.Hour),
+			EndsAt:    time.Now().AddDate(1, 0, 0),
+			TeamUUIDs: []uuid.UUID{home.UUID, away.UUID},
+		},
+	})
+	s.Require().NoError(err)
+
+	err = scouting.UpdateOrganizationLeagues(ctx, s.sdb, "o1", []uuid.UUID{l.UUID})
+	s.Require().NoError(err)
+
+	for i := range 2 {
+		_, err = scouting.CreateMatch(ctx, s.sdb, "o1", "test_scout", scouting.NewMatch{
+			LeagueUUID:   l.UUID,
+			AwayTeamUUID: away.UUID,
+			HomeTeamUUID: home.UUID,
+			StartsAt:     time.Now().Add(time.Duration(i+1) * time.Hour),
+		})
+		s.Require().NoError(err)
+	}
+
+	get := func(q url.Values) []byte {
+		req := httptest.NewRequest(http.MethodGet, "/v1/matches/active?"+q.Encode(), http.NoBody)
+		req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), &clerk.SessionClaims{
+			Claims: clerk.Claims{
+				ActiveOrganizationID: "o1",
+			},
+		}))
+
+		rec := httptest.NewRecorder()
+		s.rt.getActiveMatches(rec, req)
+		s.Require().Equal(http.StatusOK, rec.Code)
+
+		return rec.Body.Bytes()
+	}
+
+	// Without limit and cursor the bare array is returned.
+	var mm []struct {
+		ID string `json:"id"`
+	}
+
+	s.Require().NoError(json.Unmarshal(get(url.Values{}), &mm))
+	s.Assert().Len(mm, 2)
+
+	var p struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+		Cursor string `json:"cursor"`
+	}
+
+	s.Require().NoError(json.Unmarshal(get(url.Values{"limit": {"1"}}), &p))
+	s.Assert().Len(p.Items, 1)
+	s.Assert().NotEmpty(p.Cursor)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type matchReschedule struct {
+	UUID             uuid.UUID `json:"uuid"`
+	PreviousStartsAt time.Time `json:"previous_starts_at"`
+	StartsAt         time.Time `json:"starts_at"`
+	Reason           string    `json:"reason,omitempty"`
+	RescheduledBy    string    `json:"rescheduled_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func newMatchReschedule(mr scouting.MatchReschedule) matchReschedule {
+	return matchReschedule{
+		UUID:             mr.UUID,
+		PreviousStartsAt: mr.PreviousStartsAt,
+		StartsAt:         mr.StartsAt,
+		Reason:           mr.Reason,
+		RescheduledBy:    mr.RescheduledBy,
+		CreatedAt:        mr.CreatedAt,
+	}
+}
+
+func (rt *Server) updateMatchStatus(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	var req struct {
+		Status scouting.MatchStatus `json:"status"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	m, err := scouting.UpdateMatchStatus(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, matchUUID, req.Status)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newMatch(m))
+}
+
+func (rt *Server) rescheduleMatch(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	var nr scouting.NewMatchReschedule
+
+	if err := json.NewDecoder(r.Body).Decode(&nr); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	m, _, err := scouting.RescheduleMatch(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, matchUUID, nr)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newMatch(m))
+}
+
+func (rt *Server) getMatchReschedules(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	mrr, err := scouting.SelectMatchReschedules(r.Context(), rt.sdb, claims.ActiveOrganizationID, matchUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]matchReschedule, len(mrr))
+
+	for i, mr := range mrr {
+		enc[i] = newMatchReschedule(mr)
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
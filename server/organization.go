@@ -12,14 +12,18 @@ import (
 )
 
 type organization struct {
-	ID             string         `json:"id"`
-	ScoutingConfig scoutingConfig `json:"scouting_config"`
+	ID                    string         `json:"id"`
+	ScoutingConfig        scoutingConfig `json:"scouting_config"`
+	ScoutingConfigVersion uint           `json:"scouting_config_version"`
+	MaxScoreDiscrepancy   uint           `json:"max_score_discrepancy"`
 }
 
 func newOrganization(o scouting.Organization) organization {
 	return organization{
-		ID:             o.ID,
-		ScoutingConfig: newScoutingConfig(o.ScoutingConfig),
+		ID:                    o.ID,
+		ScoutingConfig:        newScoutingConfig(o.ScoutingConfig),
+		ScoutingConfigVersion: o.ScoutingConfigVersion,
+		MaxScoreDiscrepancy:   o.MaxScoreDiscrepancy,
 	}
 }
 
@@ -96,3 +100,86 @@ func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request) {
 
 	JSON(w, http.StatusCreated, newOrganization(oo[0]))
 }
+
+func (s *Server) updateOrganizationSettings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var in scouting.OrganizationSettings
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	o, err := scouting.UpdateOrganizationSettings(r.Context(), s.sdb, claims.ActiveOrganizationID, in)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newOrganization(o))
+}
+
+func (s *Server) getScoutingConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	filter := scouting.OrganizationFilter{
+		IDs: []string{claims.ActiveOrganizationID},
+	}
+
+	oo, err := scouting.SelectOrganizations(r.Context(), s.sdb, filter)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	if len(oo) == 0 {
+		NotFound(w, "organization not found")
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newScoutingConfig(oo[0].ScoutingConfig))
+}
+
+func (s *Server) updateScoutingConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var in scouting.ScoutingConfig
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	o, err := scouting.UpdateScoutingConfig(r.Context(), s.sdb, claims.ActiveOrganizationID, in)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newScoutingConfig(o.ScoutingConfig))
+}
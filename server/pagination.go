@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// pageLimit returns the number of items a page holds. Lists are only paged
+// when the limit or a cursor is sent, a cursor alone falls back to the
+// default limit. Zero means all items are returned.
+func pageLimit(limit uint64, cursor string) (uint64, error) {
+	switch {
+	case limit > maxPageLimit:
+		return 0, errors.New("limit cannot exceed 200")
+	case limit == 0 && cursor != "":
+		return defaultPageLimit, nil
+	}
+
+	return limit, nil
+}
+
+// fetchLimit returns the number of rows to select for a page, one more than
+// it holds to tell whether another page follows. Zero selects all rows.
+func fetchLimit(limit uint64) uint64 {
+	if limit == 0 {
+		return 0
+	}
+
+	return limit + 1
+}
+
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeCursor returns the key of the last item of the previous page. Keys
+// of the first page are empty.
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errors.New("invalid cursor")
+	}
+
+	return string(key), nil
+}
+
+func decodeUUIDCursor(cursor string) (uuid.UUID, error) {
+	key, err := decodeCursor(cursor)
+	if err != nil || key == "" {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.FromString(key)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid cursor")
+	}
+
+	return id, nil
+}
+
+func decodeUintCursor(cursor string) (uint, error) {
+	key, err := decodeCursor(cursor)
+	if err != nil || key == "" {
+		return 0, err
+	}
+
+	n, err := strconv.ParseUint(key, 10, 0)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+
+	return uint(n), nil
+}
+
+// nextPage trims items selected with one extra row to the limit and returns
+// the cursor of the following page, which is empty on the last one and when
+// the list is not paged.
+func nextPage[T any](items []T, limit uint64, key func(T) string) ([]T, string) {
+	if limit == 0 || uint64(len(items)) <= limit {
+		return items, ""
+	}
+
+	items = items[:limit]
+
+	return items, encodeCursor(key(items[limit-1]))
+}
+
+// page wraps the items of a paged list in the cursor envelope. Unpaged lists
+// are returned as the bare array clients received before lists were paged.
+func page[T any](items []T, limit uint64, cursor string) any {
+	if limit == 0 {
+		return items
+	}
+
+	return Paginated(items, cursor)
+}
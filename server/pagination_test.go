@@ -0,0 +1,189 @@
+package server
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pageLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Limit  uint64
+		Cursor string
+		Result uint64
+		Error  string
+	}{
+		"Not paged": {
+			Limit:  0,
+			Result: 0,
+		},
+		"Default": {
+			Limit:  0,
+			Cursor: encodeCursor("account"),
+			Result: defaultPageLimit,
+		},
+		"Custom": {
+			Limit:  10,
+			Result: 10,
+		},
+		"Max": {
+			Limit:  maxPageLimit,
+			Result: maxPageLimit,
+		},
+		"Too large": {
+			Limit: maxPageLimit + 1,
+			Error: "limit cannot exceed 200",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			res, err := pageLimit(tc.Limit, tc.Cursor)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Result, res)
+		})
+	}
+}
+
+func Test_decodeUUIDCursor(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+
+	tests := map[string]struct {
+		Cursor string
+		Result uuid.UUID
+		Error  string
+	}{
+		"First page": {
+			Cursor: "",
+			Result: uuid.Nil,
+		},
+		"Valid": {
+			Cursor: encodeCursor(id.String()),
+			Result: id,
+		},
+		"Invalid encoding": {
+			Cursor: "!!",
+			Error:  "invalid cursor",
+		},
+		"Invalid key": {
+			Cursor: encodeCursor("account"),
+			Error:  "invalid cursor",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			res, err := decodeUUIDCursor(tc.Cursor)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Result, res)
+		})
+	}
+}
+
+func Test_nextPage(t *testing.T) {
+	t.Parallel()
+
+	key := func(i int) string {
+		return strconv.Itoa(i)
+	}
+
+	tests := map[string]struct {
+		Items  []int
+		Limit  uint64
+		Result []int
+		Cursor string
+	}{
+		"Empty": {
+			Items:  []int{},
+			Limit:  3,
+			Result: []int{},
+		},
+		"Last page": {
+			Items:  []int{1, 2},
+			Limit:  3,
+			Result: []int{1, 2},
+		},
+		"Full last page": {
+			Items:  []int{1, 2, 3},
+			Limit:  3,
+			Result: []int{1, 2, 3},
+		},
+		"More pages": {
+			Items:  []int{1, 2, 3, 4},
+			Limit:  3,
+			Result: []int{1, 2, 3},
+			Cursor: encodeCursor("3"),
+		},
+		"Not paged": {
+			Items:  []int{1, 2, 3, 4},
+			Limit:  0,
+			Result: []int{1, 2, 3, 4},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			res, cursor := nextPage(tc.Items, tc.Limit, key)
+
+			assert.Equal(t, tc.Result, res)
+			assert.Equal(t, tc.Cursor, cursor)
+
+			if cursor != "" {
+				k, err := decodeCursor(cursor)
+				assert.NoError(t, err)
+				assert.Equal(t, key(res[len(res)-1]), k)
+			}
+		})
+	}
+}
+
+func Test_page(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Limit  uint64
+		Cursor string
+		Result string
+	}{
+		"Not paged": {
+			Limit:  0,
+			Result: `[1,2]`,
+		},
+		"Paged": {
+			Limit:  2,
+			Cursor: encodeCursor("2"),
+			Result: `{"items":[1,2],"cursor":"` + encodeCursor("2") + `"}`,
+		},
+		"Last page": {
+			Limit:  2,
+			Result: `{"items":[1,2],"cursor":""}`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			res, err := json.Marshal(page([]int{1, 2}, tc.Limit, tc.Cursor))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.Result, string(res))
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type possession struct {
+	UUID           uuid.UUID     `json:"uuid"`
+	AccountID      string        `json:"account_id"`
+	Sequence       uint          `json:"sequence"`
+	ActionID       string        `json:"action_id"`
+	ActionOptionID *string       `json:"action_option_id,omitempty"`
+	OutcomeID      string        `json:"outcome_id"`
+	Mode           scouting.Mode `json:"mode"`
+	Play           *string       `json:"play,omitempty"`
+	Rule           *string       `json:"rule,omitempty"`
+	GameTime       *uint         `json:"game_time,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+func newPossession(p scouting.Possession) possession {
+	enc := possession{
+		UUID:      p.UUID,
+		AccountID: p.AccountID,
+		Sequence:  p.Sequence,
+		ActionID:  p.ActionID,
+		OutcomeID: p.OutcomeID,
+		Mode:      p.Mode,
+		CreatedAt: p.CreatedAt,
+	}
+
+	if p.ActionOptionID.Valid {
+		enc.ActionOptionID = &p.ActionOptionID.V
+	}
+
+	if p.Play.Valid {
+		enc.Play = &p.Play.V
+	}
+
+	if p.Rule.Valid {
+		enc.Rule = &p.Rule.V
+	}
+
+	if p.GameTime.Valid {
+		enc.GameTime = &p.GameTime.V
+	}
+
+	return enc
+}
+
+func newPossessions(pp []scouting.Possession) []possession {
+	enc := make([]possession, len(pp))
+
+	for i, p := range pp {
+		enc[i] = newPossession(p)
+	}
+
+	return enc
+}
+
+func (rt *Server) appendPossessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	var in struct {
+		Possessions []scouting.NewPossession `json:"possessions"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	pp, err := scouting.AppendPossessions(
+		r.Context(),
+		rt.sdb,
+		claims.ActiveOrganizationID,
+		claims.Subject,
+		matchUUID,
+		in.Possessions,
+	)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newPossessions(pp))
+}
+
+func (rt *Server) getPossessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	f := scouting.PossessionFilter{
+		MatchUUID:           &matchUUID,
+		AccountID:           &claims.Subject,
+		MatchOrganizationID: &claims.ActiveOrganizationID,
+	}
+
+	pp, err := scouting.SelectPossessions(r.Context(), rt.sdb, f)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newPossessions(pp))
+}
+
+type timelineEvent struct {
+	possession
+	ScoutMode    scouting.Mode    `json:"scout_mode"`
+	ScoutSubmode scouting.Submode `json:"scout_submode"`
+}
+
+func (rt *Server) getMatchTimeline(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	ee, err := scouting.MatchTimeline(r.Context(), rt.sdb, claims.ActiveOrganizationID, matchUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]timelineEvent, len(ee))
+
+	for i, e := range ee {
+		enc[i] = timelineEvent{
+			possession:   newPossession(e.Possession),
+			ScoutMode:    e.ScoutMode,
+			ScoutSubmode: e.ScoutSubmode,
+		}
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
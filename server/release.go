@@ -0,0 +1,168 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type matchScoutAudit struct {
+	UUID            uuid.UUID        `json:"uuid"`
+	Action          string           `json:"action"`
+	AccountID       string           `json:"account_id"`
+	TargetAccountID *string          `json:"target_account_id,omitempty"`
+	Mode            scouting.Mode    `json:"mode"`
+	Submode         scouting.Submode `json:"submode"`
+	Possessions     int64            `json:"possessions"`
+	PerformedBy     string           `json:"performed_by"`
+	CreatedAt       time.Time        `json:"created_at"`
+}
+
+func newMatchScoutAudit(ma scouting.MatchScoutAudit) matchScoutAudit {
+	enc := matchScoutAudit{
+		UUID:        ma.UUID,
+		Action:      string(ma.Action),
+		AccountID:   ma.AccountID,
+		Mode:        ma.Mode,
+		Submode:     ma.Submode,
+		Possessions: ma.Possessions,
+		PerformedBy: ma.PerformedBy,
+		CreatedAt:   ma.CreatedAt,
+	}
+
+	if ma.TargetAccountID.Valid {
+		enc.TargetAccountID = &ma.TargetAccountID.V
+	}
+
+	return enc
+}
+
+// releaseMatchScout releases the slot of the current account.
+func (rt *Server) releaseMatchScout(w http.ResponseWriter, r *http.Request) {
+	rt.release(w, r, false)
+}
+
+// releaseAccountMatchScout releases the slot of any organization account.
+func (rt *Server) releaseAccountMatchScout(w http.ResponseWriter, r *http.Request) {
+	rt.release(w, r, true)
+}
+
+func (rt *Server) release(w http.ResponseWriter, r *http.Request, byPath bool) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	aid := claims.Subject
+
+	if byPath {
+		aid = r.PathValue("accountID")
+	}
+
+	if err := scouting.ReleaseMatchScout(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, matchUUID, aid); err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handOverMatchScout hands over the slot of the current account.
+func (rt *Server) handOverMatchScout(w http.ResponseWriter, r *http.Request) {
+	rt.handOver(w, r, false)
+}
+
+// handOverAccountMatchScout hands over the slot of any organization account.
+func (rt *Server) handOverAccountMatchScout(w http.ResponseWriter, r *http.Request) {
+	rt.handOver(w, r, true)
+}
+
+func (rt *Server) handOver(w http.ResponseWriter, r *http.Request, byPath bool) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	var req struct {
+		AccountID string `json:"account_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	aid := claims.Subject
+
+	if byPath {
+		aid = r.PathValue("accountID")
+	}
+
+	ms, err := scouting.HandOverMatchScout(r.Context(), rt.sdb, claims.ActiveOrganizationID, claims.Subject, matchUUID, aid, req.AccountID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newMatchScout(ms))
+}
+
+func (rt *Server) getMatchScoutAudits(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	matchUUID, err := uuid.FromString(r.PathValue("matchID"))
+	if err != nil {
+		BadRequest(w, "invalid match identifier format")
+
+		return
+	}
+
+	maa, err := scouting.SelectMatchScoutAudits(r.Context(), rt.sdb, claims.ActiveOrganizationID, matchUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]matchScoutAudit, len(maa))
+
+	for i, ma := range maa {
+		enc[i] = newMatchScoutAudit(ma)
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
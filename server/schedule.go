@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+const maxScheduleSize = 1 << 20
+
+type fixtureError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type scheduleImport struct {
+	Matches []match        `json:"matches"`
+	Errors  []fixtureError `json:"errors"`
+	Applied bool           `json:"applied"`
+}
+
+func newScheduleImport(si scouting.ScheduleImport) scheduleImport {
+	enc := scheduleImport{
+		Matches: make([]match, len(si.Matches)),
+		Errors:  make([]fixtureError, len(si.Errors)),
+		Applied: si.Applied,
+	}
+
+	for i, m := range si.Matches {
+		enc.Matches[i] = newMatch(m)
+	}
+
+	for i, fe := range si.Errors {
+		enc.Errors[i] = fixtureError{
+			Line:    fe.Line,
+			Message: fe.Message,
+		}
+	}
+
+	return enc
+}
+
+func (rt *Server) importSchedule(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	leagueUUID, err := uuid.FromString(r.PathValue("leagueID"))
+	if err != nil {
+		BadRequest(w, "invalid league identifier format")
+
+		return
+	}
+
+	var qr struct {
+		SeasonUUID uuid.UUID `schema:"season_uuid"`
+		DryRun     bool      `schema:"dry_run"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxScheduleSize))
+	if err != nil {
+		BadRequest(w, "invalid body")
+
+		return
+	}
+
+	si, err := scouting.ImportSchedule(
+		r.Context(),
+		rt.sdb,
+		claims.ActiveOrganizationID,
+		claims.Subject,
+		leagueUUID,
+		qr.SeasonUUID,
+		bytes.NewReader(data),
+		qr.DryRun,
+	)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newScheduleImport(si))
+}
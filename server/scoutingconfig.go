@@ -27,6 +27,7 @@ type outcome struct {
 	Points             uint     `json:"points"`
 	EndedInShot        bool     `json:"ended_in_shot"`
 	PossibleFreeThrows uint     `json:"possible_free_throws"`
+	Turnover           bool     `json:"turnover"`
 	StatisticTags      []string `json:"statistic_tags,omitempty"`
 }
 
@@ -75,6 +76,7 @@ func newOutcome(o scouting.Outcome) outcome {
 		Points:             o.Points,
 		EndedInShot:        o.EndedInShot,
 		PossibleFreeThrows: o.PossibleFreeThrows,
+		Turnover:           o.Turnover,
 		StatisticTags:      o.StatisticTags,
 	}
 }
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type season struct {
+	UUID        uuid.UUID             `json:"uuid"`
+	LeagueUUID  uuid.UUID             `json:"league_uuid"`
+	Name        string                `json:"name"`
+	StartsAt    time.Time             `json:"starts_at"`
+	EndsAt      time.Time             `json:"ends_at"`
+	Teams       []team                `json:"teams,omitempty"`
+	TieBreakers []scouting.TieBreaker `json:"standings_tie_breakers"`
+}
+
+func newSeason(s scouting.Season, teams []scouting.Team) season {
+	tt := make([]team, len(teams))
+
+	for i, t := range teams {
+		tt[i] = newTeam(t)
+	}
+
+	tbb := []scouting.TieBreaker{}
+
+	if len(s.StandingsTieBreakers) > 0 {
+		tbb = s.StandingsTieBreakers
+	}
+
+	return season{
+		UUID:        s.UUID,
+		LeagueUUID:  s.LeagueUUID,
+		Name:        s.Name,
+		StartsAt:    s.StartsAt,
+		EndsAt:      s.EndsAt,
+		Teams:       tt,
+		TieBreakers: tbb,
+	}
+}
+
+func (rt *Server) createSeason(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	leagueUUID, err := uuid.FromString(r.PathValue("leagueID"))
+	if err != nil {
+		BadRequest(w, "invalid league identifier format")
+
+		return
+	}
+
+	var ns scouting.NewSeason
+
+	if err := json.NewDecoder(r.Body).Decode(&ns); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	s, err := scouting.CreateSeason(r.Context(), rt.sdb, claims.ActiveOrganizationID, leagueUUID, ns)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	tt, err := scouting.SelectTeams(r.Context(), rt.sdb, scouting.TeamFilter{
+		SeasonUUID:      s.UUID,
+		OrganizationID:  claims.ActiveOrganizationID,
+		IncludeArchived: true,
+	})
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusCreated, newSeason(s, tt))
+}
+
+func (rt *Server) getSeasons(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	leagueUUID, err := uuid.FromString(r.PathValue("leagueID"))
+	if err != nil {
+		BadRequest(w, "invalid league identifier format")
+
+		return
+	}
+
+	ss, err := scouting.SelectSeasons(r.Context(), rt.sdb, scouting.SeasonFilter{
+		LeagueUUID:     leagueUUID,
+		OrganizationID: claims.ActiveOrganizationID,
+	})
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]season, len(ss))
+
+	for i, s := range ss {
+		enc[i] = newSeason(s, nil)
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
+
+func (rt *Server) updateSeasonTeams(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	seasonUUID, err := uuid.FromString(r.PathValue("seasonID"))
+	if err != nil {
+		BadRequest(w, "invalid season identifier format")
+
+		return
+	}
+
+	var in struct {
+		TeamUUIDs []uuid.UUID `json:"team_uuids"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	err = scouting.UpdateSeasonTeams(r.Context(), rt.sdb, claims.ActiveOrganizationID, seasonUUID, in.TeamUUIDs)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, struct{}{})
+}
+
+func (rt *Server) updateSeasonTieBreakers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	seasonUUID, err := uuid.FromString(r.PathValue("seasonID"))
+	if err != nil {
+		BadRequest(w, "invalid season identifier format")
+
+		return
+	}
+
+	var in struct {
+		StandingsTieBreakers scouting.TieBreakers `json:"standings_tie_breakers"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	s, err := scouting.UpdateSeasonTieBreakers(r.Context(), rt.sdb, claims.ActiveOrganizationID, seasonUUID, in.StandingsTieBreakers)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newSeason(s, nil))
+}
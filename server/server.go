@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/go-pkgz/routegroup"
 	"github.com/gofrs/uuid/v5"
@@ -46,6 +47,15 @@ func New(sdb *sqlx.DB, addr string, promKey []byte, dev bool) *Server {
 		return reflect.ValueOf(u)
 	})
 
+	dec.RegisterConverter(time.Time{}, func(s string) reflect.Value {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return reflect.Value{}
+		}
+
+		return reflect.ValueOf(t)
+	})
+
 	s := &Server{
 		sdb:     sdb,
 		decoder: dec,
@@ -121,6 +131,17 @@ func (rt *Server) handler() http.Handler {
 	group.Mount("/v1").Route(func(b *routegroup.Bundle) {
 		b.With(withOrg).HandleFunc("POST /organizations", rt.createOrganization)
 		b.With(withOrg).HandleFunc("GET /organization", rt.getOrganization)
+		b.With(withOrgPerm(access.PermissionManageOrganizations)).HandleFunc("PUT /organization/settings", rt.updateOrganizationSettings)
+		b.With(withOrgPerm(access.PermissionManageConfigs)).HandleFunc("GET /organization/scouting-config", rt.getScoutingConfig)
+		b.With(withOrgPerm(access.PermissionManageConfigs)).HandleFunc("PUT /organization/scouting-config", rt.updateScoutingConfig)
+		b.With(withOrgPerm(access.PermissionManageConfigs)).HandleFunc("GET /organization/scouting-config/export", rt.exportScoutingConfig)
+		b.With(withOrgPerm(access.PermissionManageConfigs)).HandleFunc("POST /organization/scouting-config/import", rt.importScoutingConfig)
+		b.With(withOrgPerm(access.PermissionManageConfigs)).HandleFunc("GET /organization/scouting-config/versions", rt.getScoutingConfigVersions)
+		b.With(withOrgPerm(access.PermissionManageConfigs)).HandleFunc("GET /organization/scouting-config/versions/diff", rt.diffScoutingConfigVersions)
+		b.With(withOrgPerm(access.PermissionManageLayouts)).HandleFunc("GET /organization/scouting-config/layouts", rt.getLayouts)
+		b.With(withOrgPerm(access.PermissionManageLayouts)).HandleFunc("POST /organization/scouting-config/layouts", rt.createLayout)
+		b.With(withOrgPerm(access.PermissionManageLayouts)).HandleFunc("PUT /organization/scouting-config/layouts/{layoutName}", rt.updateLayout)
+		b.With(withOrgPerm(access.PermissionManageLayouts)).HandleFunc("DELETE /organization/scouting-config/layouts/{layoutName}", rt.deleteLayout)
 
 		b.With(withOrg).HandleFunc("POST /accounts", rt.createAccount)
 		b.With(withOrg).HandleFunc("GET /account", rt.getAccount)
@@ -128,20 +149,57 @@ func (rt *Server) handler() http.Handler {
 
 		b.With(withOrgPerm(access.PermissionManageTeams)).HandleFunc("POST /teams", rt.createTeam)
 		b.With(withOrg).HandleFunc("GET /teams", rt.getTeams)
+		b.With(withOrgPerm(access.PermissionManageTeams)).HandleFunc("PATCH /teams/{teamID}", rt.updateTeam)
+		b.With(withOrgPerm(access.PermissionManageTeams)).HandleFunc("POST /teams/{teamID}/archive", rt.archiveTeam)
 
 		b.With(withOrg).HandleFunc("GET /leagues", rt.getLeagues)
 		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("POST /leagues", rt.createLeague)
 		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("PUT /organization/leagues", rt.updateOrganizationLeagues)
+		b.With(withOrg).HandleFunc("GET /leagues/{leagueID}/seasons", rt.getSeasons)
+		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("POST /leagues/{leagueID}/seasons", rt.createSeason)
+		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("PUT /seasons/{seasonID}/teams", rt.updateSeasonTeams)
+		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("PUT /seasons/{seasonID}/tie-breakers", rt.updateSeasonTieBreakers)
+		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("POST /leagues/{leagueID}/schedule/import", rt.importSchedule)
+		b.With(withOrg).HandleFunc("GET /leagues/{leagueID}/standings", rt.getLeagueStandings)
+		b.With(withOrg).HandleFunc("GET /leagues/{leagueID}/analytics/actions", rt.getLeagueActionAnalytics)
+		b.With(withOrg).HandleFunc("GET /statistic-tags", rt.getStatisticTags)
 
 		b.HandleFunc("POST /matches", rt.createMatch)
 		b.With(withOrg).HandleFunc("GET /matches/finished", rt.getFinishedMatches)
 		b.With(withOrg).HandleFunc("GET /matches/active", rt.getActiveMatches)
+		b.With(withOrg).HandleFunc("GET /matches/coverage", rt.getMatchCoverage)
 		b.HandleFunc("POST /matches/{matchID}/finish", rt.finishMatch)
 		b.HandleFunc("GET /matches/{matchID}", rt.getMatch)
+		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("PUT /matches/{matchID}/status", rt.updateMatchStatus)
+		b.With(withOrgPerm(access.PermissionManageLeagues)).HandleFunc("POST /matches/{matchID}/reschedule", rt.rescheduleMatch)
+		b.With(withOrg).HandleFunc("GET /matches/{matchID}/reschedules", rt.getMatchReschedules)
 
 		b.With(withOrg).HandleFunc("GET /matches/{matchID}/scouts", rt.getMatchScouts)
 		b.HandleFunc("POST /matches/{matchID}/scout", rt.scoutMatch)
+		b.With(withOrgPerm(access.PermissionAssignScouts)).HandleFunc("POST /matches/{matchID}/assignments", rt.assignMatchScout)
+		b.With(withOrg).HandleFunc("POST /matches/{matchID}/assignment/accept", rt.acceptMatchScoutAssignment)
+		b.With(withOrg).HandleFunc("POST /matches/{matchID}/assignment/decline", rt.declineMatchScoutAssignment)
+		b.With(withOrg).HandleFunc("POST /matches/{matchID}/scout/release", rt.releaseMatchScout)
+		b.With(withOrg).HandleFunc("POST /matches/{matchID}/scout/handover", rt.handOverMatchScout)
+		b.With(withOrgPerm(access.PermissionAssignScouts)).HandleFunc("POST /matches/{matchID}/scouts/{accountID}/release", rt.releaseAccountMatchScout)
+		b.With(withOrgPerm(access.PermissionAssignScouts)).HandleFunc("POST /matches/{matchID}/scouts/{accountID}/handover", rt.handOverAccountMatchScout)
+		b.With(withOrg).HandleFunc("GET /matches/{matchID}/scouts/audit", rt.getMatchScoutAudits)
+
+		b.With(withOrg).HandleFunc("POST /availability", rt.createAvailability)
+		b.With(withOrg).HandleFunc("GET /availability", rt.getAvailabilities)
+		b.With(withOrg).HandleFunc("DELETE /availability/{availabilityID}", rt.deleteAvailability)
+		b.With(withOrgPerm(access.PermissionAssignScouts)).HandleFunc("GET /assignments/proposal", rt.proposeAssignments)
+		b.With(withOrgPerm(access.PermissionAssignScouts)).HandleFunc("POST /assignments", rt.commitAssignments)
 		b.HandleFunc("POST /matches/{matchID}/finish-scouting", rt.finishMatchScouting)
+		b.With(withOrg).HandleFunc("POST /matches/{matchID}/possessions", rt.appendPossessions)
+		b.With(withOrg).HandleFunc("GET /matches/{matchID}/possessions", rt.getPossessions)
+		b.With(withOrg).HandleFunc("GET /matches/{matchID}/boxscore", rt.getMatchBoxScore)
+		b.With(withOrg).HandleFunc("GET /matches/{matchID}/timeline", rt.getMatchTimeline)
+
+		b.With(withOrg).HandleFunc("POST /calendar/token", rt.createCalendarToken)
+		b.With(withOrg).HandleFunc("DELETE /calendar/token", rt.deleteCalendarToken)
+		b.HandleFunc("GET /calendar/{token}/matches.ics", rt.getMatchesCalendar)
+		b.HandleFunc("GET /calendar/{token}/scout.ics", rt.getScoutCalendar)
 	})
 
 	return group
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type record struct {
+	Wins   uint `json:"wins"`
+	Losses uint `json:"losses"`
+}
+
+type standing struct {
+	TeamUUID          uuid.UUID `json:"team_uuid"`
+	Played            uint      `json:"played"`
+	Wins              uint      `json:"wins"`
+	Losses            uint      `json:"losses"`
+	WinPercentage     float64   `json:"win_percentage"`
+	PointsFor         uint      `json:"points_for"`
+	PointsAgainst     uint      `json:"points_against"`
+	PointDifferential int       `json:"point_differential"`
+	Home              record    `json:"home"`
+	Away              record    `json:"away"`
+	Streak            string    `json:"streak,omitempty"`
+}
+
+func newStanding(s scouting.Standing) standing {
+	enc := standing{
+		TeamUUID:          s.TeamUUID,
+		Played:            s.Played,
+		Wins:              s.Wins,
+		Losses:            s.Losses,
+		WinPercentage:     s.WinPercentage(),
+		PointsFor:         s.PointsFor,
+		PointsAgainst:     s.PointsAgainst,
+		PointDifferential: s.PointDifferential(),
+		Home:              record(s.Home),
+		Away:              record(s.Away),
+	}
+
+	switch {
+	case s.Streak > 0:
+		enc.Streak = fmt.Sprintf("W%d", s.Streak)
+	case s.Streak < 0:
+		enc.Streak = fmt.Sprintf("L%d", -s.Streak)
+	}
+
+	return enc
+}
+
+type standings struct {
+	Season season     `json:"season"`
+	Teams  []standing `json:"teams"`
+}
+
+func (rt *Server) getLeagueStandings(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	leagueUUID, err := uuid.FromString(r.PathValue("leagueID"))
+	if err != nil {
+		BadRequest(w, "invalid league identifier format")
+
+		return
+	}
+
+	var qr struct {
+		SeasonUUID uuid.UUID `schema:"season_uuid"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	s, ss, err := scouting.LeagueStandings(r.Context(), rt.sdb, claims.ActiveOrganizationID, leagueUUID, qr.SeasonUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := standings{
+		Season: newSeason(s, nil),
+		Teams:  make([]standing, len(ss)),
+	}
+
+	for i, st := range ss {
+		enc.Teams[i] = newStanding(st)
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/sportsbydata/backend/scouting"
+)
+
+type statisticTagCount struct {
+	Tag   string `json:"tag"`
+	Count uint   `json:"count"`
+}
+
+func (rt *Server) getStatisticTags(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	var qr struct {
+		MatchUUID  uuid.UUID     `schema:"match_uuid"`
+		LeagueUUID uuid.UUID     `schema:"league_uuid"`
+		SeasonUUID uuid.UUID     `schema:"season_uuid"`
+		TeamUUID   uuid.UUID     `schema:"team_uuid"`
+		Mode       scouting.Mode `schema:"mode"`
+		From       time.Time     `schema:"from"`
+		To         time.Time     `schema:"to"`
+	}
+
+	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
+		BadRequest(w, "invalid query")
+
+		return
+	}
+
+	f := scouting.StatisticTagFilter{
+		MatchUUID:  qr.MatchUUID,
+		LeagueUUID: qr.LeagueUUID,
+		SeasonUUID: qr.SeasonUUID,
+		TeamUUID:   qr.TeamUUID,
+		Mode:       qr.Mode,
+		StartsFrom: qr.From,
+		StartsTo:   qr.To,
+	}
+
+	tcc, err := scouting.StatisticTagCounts(r.Context(), rt.sdb, claims.ActiveOrganizationID, f)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	enc := make([]statisticTagCount, len(tcc))
+
+	for i, tc := range tcc {
+		enc[i] = statisticTagCount{
+			Tag:   tc.Tag,
+			Count: tc.Count,
+		}
+	}
+
+	JSON(w, http.StatusOK, enc)
+}
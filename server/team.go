@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/gofrs/uuid/v5"
@@ -11,27 +12,50 @@ import (
 )
 
 type team struct {
-	UUID uuid.UUID `json:"uuid"`
-	Name string    `json:"name"`
+	UUID       uuid.UUID  `json:"uuid"`
+	Name       string     `json:"name"`
+	Shared     bool       `json:"shared"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 }
 
 func newTeam(t scouting.Team) team {
-	return team{
-		UUID: t.UUID,
-		Name: t.Name,
+	enc := team{
+		UUID:   t.UUID,
+		Name:   t.Name,
+		Shared: !t.OrganizationID.Valid,
 	}
+
+	if t.ArchivedAt.Valid {
+		enc.ArchivedAt = &t.ArchivedAt.V
+	}
+
+	return enc
 }
 
 func (rt *Server) createTeam(w http.ResponseWriter, r *http.Request) {
-	var nt scouting.NewTeam
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	// Shared teams have no owner to manage them, so organizations can only
+	// create their own.
+	var in struct {
+		Name string `json:"name"`
+	}
 
-	if err := json.NewDecoder(r.Body).Decode(&nt); err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
 		BadRequest(w, "invalid json")
 
 		return
 	}
 
-	t, err := scouting.CreateTeam(r.Context(), nt, rt.sdb)
+	t, err := scouting.CreateTeam(r.Context(), rt.sdb, claims.ActiveOrganizationID, scouting.NewTeam{
+		Name: in.Name,
+	})
 	if err != nil {
 		HandleError(w, err)
 
@@ -41,6 +65,66 @@ func (rt *Server) createTeam(w http.ResponseWriter, r *http.Request) {
 	JSON(w, http.StatusCreated, newTeam(t))
 }
 
+func (rt *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	teamUUID, err := uuid.FromString(r.PathValue("teamID"))
+	if err != nil {
+		BadRequest(w, "invalid team identifier format")
+
+		return
+	}
+
+	var tu scouting.TeamUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&tu); err != nil {
+		BadRequest(w, "invalid json")
+
+		return
+	}
+
+	t, err := scouting.UpdateTeam(r.Context(), rt.sdb, claims.ActiveOrganizationID, teamUUID, tu)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newTeam(t))
+}
+
+func (rt *Server) archiveTeam(w http.ResponseWriter, r *http.Request) {
+	claims, ok := clerk.SessionClaimsFromContext(r.Context())
+	if !ok {
+		slog.Error("session not found in context")
+		Internal(w)
+
+		return
+	}
+
+	teamUUID, err := uuid.FromString(r.PathValue("teamID"))
+	if err != nil {
+		BadRequest(w, "invalid team identifier format")
+
+		return
+	}
+
+	t, err := scouting.ArchiveTeam(r.Context(), rt.sdb, claims.ActiveOrganizationID, teamUUID)
+	if err != nil {
+		HandleError(w, err)
+
+		return
+	}
+
+	JSON(w, http.StatusOK, newTeam(t))
+}
+
 func (rt *Server) getTeams(w http.ResponseWriter, r *http.Request) {
 	claims, ok := clerk.SessionClaimsFromContext(r.Context())
 	if !ok {
@@ -51,8 +135,12 @@ func (rt *Server) getTeams(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var qr struct {
-		LeagueUUID uuid.UUID   `schema:"league_uuid"`
-		TeamUUIDs  []uuid.UUID `schema:"team_uuids"`
+		LeagueUUID      uuid.UUID   `schema:"league_uuid"`
+		SeasonUUID      uuid.UUID   `schema:"season_uuid"`
+		TeamUUIDs       []uuid.UUID `schema:"team_uuids"`
+		IncludeArchived bool        `schema:"include_archived"`
+		Cursor          string      `schema:"cursor"`
+		Limit           uint64      `schema:"limit"`
 	}
 
 	if err := rt.decoder.Decode(&qr, r.URL.Query()); err != nil {
@@ -61,10 +149,28 @@ func (rt *Server) getTeams(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	after, err := decodeUUIDCursor(qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
+	limit, err := pageLimit(qr.Limit, qr.Cursor)
+	if err != nil {
+		BadRequest(w, err.Error())
+
+		return
+	}
+
 	f := scouting.TeamFilter{
-		UUIDs:          qr.TeamUUIDs,
-		OrganizationID: claims.ActiveOrganizationID,
-		LeagueUUID:     qr.LeagueUUID,
+		UUIDs:           qr.TeamUUIDs,
+		OrganizationID:  claims.ActiveOrganizationID,
+		LeagueUUID:      qr.LeagueUUID,
+		SeasonUUID:      qr.SeasonUUID,
+		IncludeArchived: qr.IncludeArchived,
+		After:           after,
+		Limit:           fetchLimit(limit),
 	}
 
 	tt, err := scouting.SelectTeams(r.Context(), rt.sdb, f)
@@ -74,11 +180,15 @@ func (rt *Server) getTeams(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tt, cursor := nextPage(tt, limit, func(t scouting.Team) string {
+		return t.UUID.String()
+	})
+
 	enc := make([]team, len(tt))
 
 	for i, t := range tt {
 		enc[i] = newTeam(t)
 	}
 
-	JSON(w, http.StatusOK, enc)
+	JSON(w, http.StatusOK, page(enc, limit, cursor))
 }